@@ -0,0 +1,171 @@
+package ddderr
+
+import "errors"
+
+// groupedError is implemented by every error type of this package (i.e. Error and Errors)
+type groupedError interface {
+	error
+	Group() string
+	IsGroup(group string) bool
+	IsDomain() bool
+	IsInfrastructure() bool
+}
+
+var (
+	_ groupedError = Error{}
+	_ groupedError = Errors{}
+)
+
+// finds the outermost error type of this package within the given error chain
+func asGrouped(err error) (groupedError, bool) {
+	var target groupedError
+	if err == nil || !errors.As(err, &target) {
+		return nil, false
+	}
+	return target, true
+}
+
+// As finds the outermost Error within the given error chain
+//
+// Returns false if no Error was found or if the outermost DDD error is an Errors collection
+func As(err error) (Error, bool) {
+	target, ok := asGrouped(err)
+	if !ok {
+		return Error{}, false
+	}
+	customErr, ok := target.(Error)
+	return customErr, ok
+}
+
+// IsGroup checks if the outermost DDD error within the given error chain belongs to the given error group
+func IsGroup(err error, group string) bool {
+	target, ok := asGrouped(err)
+	return ok && target.IsGroup(group)
+}
+
+// IsDomain checks if the outermost DDD error within the given error chain belongs to Domain error group
+func IsDomain(err error) bool {
+	target, ok := asGrouped(err)
+	return ok && target.IsDomain()
+}
+
+// IsInfrastructure checks if the outermost DDD error within the given error chain belongs to Infrastructure
+// error group
+func IsInfrastructure(err error) bool {
+	target, ok := asGrouped(err)
+	return ok && target.IsInfrastructure()
+}
+
+// IsRemoteCall checks if the outermost Error within the given error chain belongs to Failed Remote Call error
+// types
+func IsRemoteCall(err error) bool {
+	customErr, ok := As(err)
+	return ok && customErr.IsRemoteCall()
+}
+
+// IsNotFound checks if the outermost Error within the given error chain belongs to Not Found error types
+func IsNotFound(err error) bool {
+	customErr, ok := As(err)
+	return ok && customErr.IsNotFound()
+}
+
+// IsAlreadyExists checks if the outermost Error within the given error chain belongs to Already Exists error
+// types
+func IsAlreadyExists(err error) bool {
+	customErr, ok := As(err)
+	return ok && customErr.IsAlreadyExists()
+}
+
+// IsOutOfRange checks if the outermost Error within the given error chain belongs to Out of Range error types
+func IsOutOfRange(err error) bool {
+	customErr, ok := As(err)
+	return ok && customErr.IsOutOfRange()
+}
+
+// IsInvalidFormat checks if the outermost Error within the given error chain belongs to Invalid Format error
+// types
+func IsInvalidFormat(err error) bool {
+	customErr, ok := As(err)
+	return ok && customErr.IsInvalidFormat()
+}
+
+// IsInvalidLength checks if the outermost Error within the given error chain belongs to Invalid Length error
+// types
+func IsInvalidLength(err error) bool {
+	customErr, ok := As(err)
+	return ok && customErr.IsInvalidLength()
+}
+
+// IsRequired checks if the outermost Error within the given error chain belongs to Required error types
+func IsRequired(err error) bool {
+	customErr, ok := As(err)
+	return ok && customErr.IsRequired()
+}
+
+// IsUnauthenticated checks if the outermost Error within the given error chain belongs to Unauthenticated
+// error types
+func IsUnauthenticated(err error) bool {
+	customErr, ok := As(err)
+	return ok && customErr.IsUnauthenticated()
+}
+
+// IsConcurrencyConflict checks if the outermost Error within the given error chain belongs to Concurrency
+// Conflict error types
+func IsConcurrencyConflict(err error) bool {
+	customErr, ok := As(err)
+	return ok && customErr.IsConcurrencyConflict()
+}
+
+// IsInvariantViolation checks if the outermost Error within the given error chain belongs to Invariant
+// Violation (business rule) error types
+func IsInvariantViolation(err error) bool {
+	customErr, ok := As(err)
+	return ok && customErr.IsInvariantViolation()
+}
+
+// IsFailedPrecondition checks if the outermost Error within the given error chain belongs to Failed
+// Precondition error types
+func IsFailedPrecondition(err error) bool {
+	customErr, ok := As(err)
+	return ok && customErr.IsFailedPrecondition()
+}
+
+// IsTimeout checks if the outermost Error within the given error chain belongs to Timeout (Deadline Exceeded)
+// error types
+func IsTimeout(err error) bool {
+	customErr, ok := As(err)
+	return ok && customErr.IsTimeout()
+}
+
+// IsCanceled checks if the outermost Error within the given error chain belongs to Canceled error types
+func IsCanceled(err error) bool {
+	customErr, ok := As(err)
+	return ok && customErr.IsCanceled()
+}
+
+// IsResourceExhausted checks if the outermost Error within the given error chain belongs to Resource Exhausted
+// error types
+func IsResourceExhausted(err error) bool {
+	customErr, ok := As(err)
+	return ok && customErr.IsResourceExhausted()
+}
+
+// IsUnavailable checks if the outermost Error within the given error chain belongs to Unavailable error types
+func IsUnavailable(err error) bool {
+	customErr, ok := As(err)
+	return ok && customErr.IsUnavailable()
+}
+
+// IsPermissionDenied checks if the outermost Error within the given error chain belongs to Permission Denied
+// error types
+func IsPermissionDenied(err error) bool {
+	customErr, ok := As(err)
+	return ok && customErr.IsPermissionDenied()
+}
+
+// IsKind checks if the outermost Error within the given error chain belongs to the given error type (or any of
+// its sub-kinds), useful for user-defined kinds
+func IsKind(err error, kind string) bool {
+	customErr, ok := As(err)
+	return ok && customErr.IsKind(kind)
+}
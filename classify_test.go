@@ -0,0 +1,131 @@
+package ddderr
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var asTestSuite = []struct {
+	InErr   error
+	ExpOk   bool
+	ExpKind string
+}{
+	{
+		InErr: nil,
+		ExpOk: false,
+	},
+	{
+		InErr: errors.New("generic error"),
+		ExpOk: false,
+	},
+	{
+		InErr:   NewNotFound("foo"),
+		ExpOk:   true,
+		ExpKind: notFound,
+	},
+	{
+		InErr:   fmt.Errorf("load user: %w", NewNotFound("user")),
+		ExpOk:   true,
+		ExpKind: notFound,
+	},
+	{
+		InErr:   fmt.Errorf("load user: %w", NewRemoteCall("localhost:5432").SetParent(NewNotFound("user"))),
+		ExpOk:   true,
+		ExpKind: remoteCall,
+	},
+	{
+		InErr:   fmt.Errorf("load user: %w", fmt.Errorf("wrapped: %w", NewRequired("name"))),
+		ExpOk:   true,
+		ExpKind: required,
+	},
+}
+
+func TestAs(t *testing.T) {
+	for _, tt := range asTestSuite {
+		t.Run("", func(t *testing.T) {
+			err, ok := As(tt.InErr)
+			assert.Equal(t, tt.ExpOk, ok)
+			assert.Equal(t, tt.ExpKind, err.Kind())
+		})
+	}
+}
+
+func TestClassifiers(t *testing.T) {
+	err := fmt.Errorf("load user: %w", NewNotFound("user"))
+	assert.True(t, IsNotFound(err))
+	assert.True(t, IsDomain(err))
+	assert.False(t, IsInfrastructure(err))
+	assert.False(t, IsAlreadyExists(err))
+
+	err = fmt.Errorf("save user: %w", NewAlreadyExists("user"))
+	assert.True(t, IsAlreadyExists(err))
+	assert.False(t, IsNotFound(err))
+
+	err = fmt.Errorf("save user: %w", NewOutOfRange("age", 18, 100))
+	assert.True(t, IsOutOfRange(err))
+
+	err = fmt.Errorf("save user: %w", NewInvalidFormat("email", "email"))
+	assert.True(t, IsInvalidFormat(err))
+
+	err = fmt.Errorf("save user: %w", NewInvalidLength("username", 3, 20, 2))
+	assert.True(t, IsInvalidLength(err))
+	assert.False(t, IsOutOfRange(err))
+
+	err = fmt.Errorf("save user: %w", NewRequired("name"))
+	assert.True(t, IsRequired(err))
+
+	// outermost DDD error is used
+	err = fmt.Errorf("save user: %w", NewRemoteCall("localhost:5432").
+		SetParent(NewNotFound("user")))
+	assert.True(t, IsRemoteCall(err))
+	assert.True(t, IsInfrastructure(err))
+	assert.False(t, IsNotFound(err))
+	assert.False(t, IsDomain(err))
+
+	err = fmt.Errorf("authenticate: %w", NewUnauthenticated("access_token"))
+	assert.True(t, IsUnauthenticated(err))
+	assert.False(t, IsPermissionDenied(err))
+
+	err = fmt.Errorf("delete user: %w", NewPermissionDenied("user", "delete"))
+	assert.True(t, IsPermissionDenied(err))
+	assert.False(t, IsUnauthenticated(err))
+
+	err = fmt.Errorf("save order: %w", NewConcurrencyConflict("order", 3, 4))
+	assert.True(t, IsConcurrencyConflict(err))
+	assert.False(t, IsAlreadyExists(err))
+
+	err = fmt.Errorf("ship order: %w", NewFailedPrecondition("order", "order must be paid"))
+	assert.True(t, IsFailedPrecondition(err))
+	assert.False(t, IsConcurrencyConflict(err))
+
+	err = fmt.Errorf("ship order: %w", NewInvariantViolation("ORDER_ALREADY_SHIPPED", "order", ""))
+	assert.True(t, IsInvariantViolation(err))
+	assert.False(t, IsFailedPrecondition(err))
+
+	err = fmt.Errorf("query users: %w", NewTimeout("localhost:5432", time.Second))
+	assert.True(t, IsTimeout(err))
+	assert.False(t, IsCanceled(err))
+	assert.False(t, IsRemoteCall(err))
+
+	err = fmt.Errorf("query users: %w", NewCanceled("localhost:5432"))
+	assert.True(t, IsCanceled(err))
+	assert.False(t, IsTimeout(err))
+
+	err = fmt.Errorf("send email: %w", NewResourceExhausted("emails", 100, time.Minute))
+	assert.True(t, IsResourceExhausted(err))
+	assert.False(t, IsUnavailable(err))
+
+	err = fmt.Errorf("send email: %w", NewUnavailable("smtp.foo.com", time.Minute))
+	assert.True(t, IsUnavailable(err))
+	assert.False(t, IsResourceExhausted(err))
+
+	err = errors.New("generic error")
+	assert.False(t, IsDomain(err))
+	assert.False(t, IsInfrastructure(err))
+	assert.False(t, IsRemoteCall(nil))
+	assert.False(t, IsRequired(nil))
+}
@@ -3,6 +3,7 @@ package ddderr
 import (
 	"strconv"
 	"strings"
+	"time"
 )
 
 const (
@@ -17,10 +18,53 @@ const (
 	required      = "Required"
 	remoteCall    = "FailedRemoteCall"
 
+	unauthenticated  = "Unauthenticated"
+	permissionDenied = "PermissionDenied"
+
+	concurrencyConflict = "ConcurrencyConflict"
+	failedPrecondition  = "FailedPrecondition"
+
+	timeout  = "DeadlineExceeded"
+	canceled = "Canceled"
+
+	resourceExhausted = "ResourceExhausted"
+	unavailable       = "Unavailable"
+
+	invalidLength = "InvalidLength"
+	tooShort      = "TooShort"
+	tooLong       = "TooLong"
+
+	invariantViolation = "InvariantViolation"
+
 	unknownDomain         = "UnknownDomain"
 	unknownInfrastructure = "UnknownInfrastructure"
 )
 
+// Kind-level and group-level sentinel errors.
+//
+// Use them along errors.Is to match any Error sharing the same kind (or group), regardless of its property
+// and its position within the error chain (e.g. errors.Is(err, ddderr.ErrNotFound))
+var (
+	ErrDomain              = newSentinel(domain, "", "Domain error", "domain error")
+	ErrInfrastructure      = newSentinel(infrastructure, "", "Infrastructure error", "infrastructure error")
+	ErrNotFound            = newSentinel(domain, notFound, "Resource not found", "not found")
+	ErrAlreadyExists       = newSentinel(domain, alreadyExists, "Resource already exists", "already exists")
+	ErrOutOfRange          = newSentinel(domain, outOfRange, "Property is out of the specified range", "out of range")
+	ErrInvalidFormat       = newSentinel(domain, invalidFormat, "Property is not a valid format", "invalid format")
+	ErrRequired            = newSentinel(domain, required, "Missing property", "required")
+	ErrInvalidLength       = newSentinel(domain, invalidLength, "Property has an invalid length", "invalid length")
+	ErrInvariantViolation  = newSentinel(domain, invariantViolation, "Business rule violated", "invariant violation")
+	ErrRemoteCall          = newSentinel(infrastructure, remoteCall, "Remote call failed", "Failed to call external resource")
+	ErrUnauthenticated     = newSentinel(domain, unauthenticated, "Unauthenticated", "unauthenticated")
+	ErrPermissionDenied    = newSentinel(domain, permissionDenied, "Permission denied", "permission denied")
+	ErrConcurrencyConflict = newSentinel(domain, concurrencyConflict, "Concurrency conflict", "concurrency conflict")
+	ErrFailedPrecondition  = newSentinel(domain, failedPrecondition, "Precondition failed", "precondition failed")
+	ErrTimeout             = newSentinel(infrastructure, timeout, "Deadline exceeded", "Timed out")
+	ErrCanceled            = newSentinel(infrastructure, canceled, "Operation canceled", "Operation canceled")
+	ErrResourceExhausted   = newSentinel(infrastructure, resourceExhausted, "Resource exhausted", "quota exhausted")
+	ErrUnavailable         = newSentinel(infrastructure, unavailable, "Service unavailable", "External resource is unavailable")
+)
+
 // Error contains specific mechanisms useful for further error mapping and other
 // specific use cases
 type Error struct {
@@ -36,6 +80,20 @@ type Error struct {
 	dynamicStatus      bool
 	limitA, limitB     int
 	formats            []string
+	action             string
+	challenge          string
+	expectedVersion    int64
+	actualVersion      int64
+	reason             string
+	duration           time.Duration
+	retryAfter         time.Duration
+	length             int
+	ruleID             string
+	rangeValue         interface{}
+	lowerBound         Bound
+	upperBound         Bound
+	sentinel           bool
+	stack              []uintptr
 }
 
 var _ error = Error{}
@@ -45,6 +103,17 @@ func (e Error) Error() string {
 	return e.Description()
 }
 
+// Group retrieves the error group (e.g. Domain, Infrastructure)
+func (e Error) Group() string {
+	return e.group
+}
+
+// SetGroup sets the error group, use RegisterGroup to set the protocol defaults of a user-defined group
+func (e Error) SetGroup(group string) Error {
+	e.group = group
+	return e
+}
+
 // Kind retrieves the error type (e.g. NotFound, AlreadyExists)
 func (e Error) Kind() string {
 	return e.kind
@@ -98,11 +167,37 @@ func (e Error) Description() string {
 		return newRemoteCallDescription(e.property)
 	case notFound:
 		return newNotFoundDescription(e.property)
+	case invalidLength:
+		return newInvalidLengthDescription(e.property, e.limitA, e.limitB, e.length)
 	case outOfRange:
+		if e.rangeValue != nil {
+			return newOutOfRangeBoundsDescription(e.property, e.rangeValue, e.lowerBound, e.upperBound)
+		}
 		return newOutOfRangeDescription(e.property, e.limitA, e.limitB)
 	case required:
 		return newRequiredDescription(e.property)
+	case unauthenticated:
+		return newUnauthenticatedDescription(e.property)
+	case permissionDenied:
+		return newPermissionDeniedDescription(e.property, e.action)
+	case concurrencyConflict:
+		return newConcurrencyConflictDescription(e.property, e.expectedVersion, e.actualVersion)
+	case failedPrecondition:
+		return newFailedPreconditionDescription(e.property, e.reason)
+	case invariantViolation:
+		return newInvariantViolationDescription(e.ruleID, e.property, e.reason)
+	case timeout:
+		return newTimeoutDescription(e.property, e.duration)
+	case canceled:
+		return newCanceledDescription(e.property)
+	case resourceExhausted:
+		return newResourceExhaustedDescription(e.property, e.limitA, e.retryAfter)
+	case unavailable:
+		return newUnavailableDescription(e.property, e.retryAfter)
 	default:
+		if entry, ok := lookupKind(e.kind); ok {
+			return newKindDescription(entry, e.property)
+		}
 		return e.description
 	}
 }
@@ -126,6 +221,15 @@ func (e Error) Status() string {
 	if !e.dynamicStatus {
 		return e.statusName
 	}
+	if e.kind == invalidLength {
+		return getSanitizedStatusName(e.property, getInvalidLengthStatus(e.limitA, e.limitB, e.length))
+	}
+	if e.kind == invariantViolation {
+		return getInvariantViolationStatus(e.ruleID, e.property)
+	}
+	if spec, ok := LookupKind(e.kind); ok {
+		return getSanitizedStatusName(e.property, spec.StatusSuffix)
+	}
 	return getSanitizedStatusName(e.property, e.kind)
 }
 
@@ -142,6 +246,124 @@ func (e Error) SetParent(err error) Error {
 	return e
 }
 
+// Unwrap returns the error parent, this allows the usage of errors.Is, errors.As and errors.Unwrap
+// through the whole error chain
+//
+// Note: Might return nil if parent was not specified
+func (e Error) Unwrap() error {
+	return e.parent
+}
+
+// Action returns the operation denied to the caller
+//
+// Note: Only available for Permission Denied error types
+func (e Error) Action() string {
+	return e.action
+}
+
+// Challenge returns the authentication challenge of the error (e.g. Bearer realm="example")
+//
+// Note: Protocol mappers might use it as the WWW-Authenticate HTTP header
+func (e Error) Challenge() string {
+	return e.challenge
+}
+
+// SetChallenge sets an authentication challenge for the error (e.g. Bearer realm="example")
+func (e Error) SetChallenge(challenge string) Error {
+	e.challenge = challenge
+	return e
+}
+
+// ExpectedVersion returns the aggregate version expected by the caller
+//
+// Note: Only available for Concurrency Conflict error types
+func (e Error) ExpectedVersion() int64 {
+	return e.expectedVersion
+}
+
+// ActualVersion returns the current aggregate version
+//
+// Note: Only available for Concurrency Conflict error types
+func (e Error) ActualVersion() int64 {
+	return e.actualVersion
+}
+
+// Reason returns the reason why a precondition failed or the message of a violated business rule
+//
+// Note: Only available for Failed Precondition and Invariant Violation error types
+func (e Error) Reason() string {
+	return e.reason
+}
+
+// RuleID returns the stable identifier of the violated business rule (e.g. ORDER_ALREADY_SHIPPED)
+//
+// Note: Only available for Invariant Violation error types
+func (e Error) RuleID() string {
+	return e.ruleID
+}
+
+// Duration returns the time elapsed before the operation timed out
+//
+// Note: Only available for Timeout error types, might return zero if duration is unknown
+func (e Error) Duration() time.Duration {
+	return e.duration
+}
+
+// Limit returns the quota limit of the exhausted resource
+//
+// Note: Only available for Resource Exhausted error types, might return zero if limit is unknown
+func (e Error) Limit() int {
+	return e.limitA
+}
+
+// RetryAfter returns the time the caller should wait before retrying the operation
+//
+// Note: Only available for Resource Exhausted and Unavailable error types, might return zero if unknown
+func (e Error) RetryAfter() time.Duration {
+	return e.retryAfter
+}
+
+// MinLength returns the minimum length allowed
+//
+// Note: Only available for Invalid Length error types
+func (e Error) MinLength() int {
+	return e.limitA
+}
+
+// MaxLength returns the maximum length allowed, zero or a negative value means there is no maximum length
+//
+// Note: Only available for Invalid Length error types
+func (e Error) MaxLength() int {
+	return e.limitB
+}
+
+// Length returns the offending length
+//
+// Note: Only available for Invalid Length error types
+func (e Error) Length() int {
+	return e.length
+}
+
+// Is reports whether the error matches the given target.
+//
+// Kind-level sentinels (e.g. ErrNotFound) match any Error of the same kind (or any of its sub-kinds) while group-level sentinels
+// (ErrDomain, ErrInfrastructure) match any Error of the same group
+func (e Error) Is(target error) bool {
+	t, ok := target.(Error)
+	if !ok || !t.sentinel {
+		return false
+	}
+	if t.kind != "" {
+		return isKind(e.kind, t.kind)
+	}
+	return e.group == t.group
+}
+
+// IsGroup checks if the error belongs to the given error group
+func (e Error) IsGroup(group string) bool {
+	return e.group == group
+}
+
 // IsDomain checks if the error belongs to Domain error group
 func (e Error) IsDomain() bool {
 	return e.group == domain
@@ -152,34 +374,115 @@ func (e Error) IsInfrastructure() bool {
 	return e.group == infrastructure
 }
 
+// IsKind checks if the error belongs to the given error type (or any of its sub-kinds), useful for
+// user-defined kinds.
+//
+// Use Kind to match the specific kind instead
+func (e Error) IsKind(kind string) bool {
+	return isKind(e.kind, kind)
+}
+
 // IsRemoteCall checks if the error belongs to Failed Remote Call error types
 func (e Error) IsRemoteCall() bool {
-	return e.kind == remoteCall
+	return isKind(e.kind, remoteCall)
 }
 
 // IsNotFound checks if the error belongs to Not Found error types
 func (e Error) IsNotFound() bool {
-	return e.kind == notFound
+	return isKind(e.kind, notFound)
 }
 
 // IsAlreadyExists checks if the error belongs to Already Exists error types
 func (e Error) IsAlreadyExists() bool {
-	return e.kind == alreadyExists
+	return isKind(e.kind, alreadyExists)
 }
 
 // IsOutOfRange checks if the error belongs to Out of Range error types
 func (e Error) IsOutOfRange() bool {
-	return e.kind == outOfRange
+	return isKind(e.kind, outOfRange)
 }
 
 // IsInvalidFormat checks if the error belongs to Invalid Format error types
 func (e Error) IsInvalidFormat() bool {
-	return e.kind == invalidFormat
+	return isKind(e.kind, invalidFormat)
+}
+
+// IsInvalidLength checks if the error belongs to Invalid Length error types (i.e. TooShort or TooLong)
+func (e Error) IsInvalidLength() bool {
+	return isKind(e.kind, invalidLength)
+}
+
+// IsTooShort checks if the error belongs to Invalid Length error types and the length is lower than the
+// minimum length
+func (e Error) IsTooShort() bool {
+	return isKind(e.kind, invalidLength) && e.length < e.limitA
+}
+
+// IsTooLong checks if the error belongs to Invalid Length error types and the length is greater than the
+// maximum length
+func (e Error) IsTooLong() bool {
+	return isKind(e.kind, invalidLength) && e.limitB > 0 && e.length > e.limitB
 }
 
 // IsRequired checks if the error belongs to Required error types
 func (e Error) IsRequired() bool {
-	return e.kind == required
+	return isKind(e.kind, required)
+}
+
+// IsUnauthenticated checks if the error belongs to Unauthenticated error types
+func (e Error) IsUnauthenticated() bool {
+	return isKind(e.kind, unauthenticated)
+}
+
+// IsPermissionDenied checks if the error belongs to Permission Denied error types
+func (e Error) IsPermissionDenied() bool {
+	return isKind(e.kind, permissionDenied)
+}
+
+// IsConcurrencyConflict checks if the error belongs to Concurrency Conflict error types
+func (e Error) IsConcurrencyConflict() bool {
+	return isKind(e.kind, concurrencyConflict)
+}
+
+// IsFailedPrecondition checks if the error belongs to Failed Precondition error types
+func (e Error) IsFailedPrecondition() bool {
+	return isKind(e.kind, failedPrecondition)
+}
+
+// IsInvariantViolation checks if the error belongs to Invariant Violation (business rule) error types
+func (e Error) IsInvariantViolation() bool {
+	return isKind(e.kind, invariantViolation)
+}
+
+// IsTimeout checks if the error belongs to Timeout (Deadline Exceeded) error types
+func (e Error) IsTimeout() bool {
+	return isKind(e.kind, timeout)
+}
+
+// IsCanceled checks if the error belongs to Canceled error types
+func (e Error) IsCanceled() bool {
+	return isKind(e.kind, canceled)
+}
+
+// IsResourceExhausted checks if the error belongs to Resource Exhausted error types
+func (e Error) IsResourceExhausted() bool {
+	return isKind(e.kind, resourceExhausted)
+}
+
+// IsUnavailable checks if the error belongs to Unavailable error types
+func (e Error) IsUnavailable() bool {
+	return isKind(e.kind, unavailable)
+}
+
+func newSentinel(group, kind, title, description string) Error {
+	return Error{
+		group:       group,
+		kind:        kind,
+		title:       title,
+		description: description,
+		statusName:  kind,
+		sentinel:    true,
+	}
 }
 
 // NewDomain creates an Error for Domain generic use cases
@@ -191,6 +494,7 @@ func NewDomain(title, description string) Error {
 		property:    "",
 		title:       title,
 		description: description,
+		stack:       callers(1),
 	}
 }
 
@@ -203,6 +507,7 @@ func NewInfrastructure(title, description string) Error {
 		property:    "",
 		title:       title,
 		description: description,
+		stack:       callers(1),
 	}
 }
 
@@ -218,6 +523,7 @@ func NewRemoteCall(externalResource string) Error {
 		title:       "Remote call failed",
 		description: newRemoteCallDescription(externalResource),
 		statusName:  "FailedRemoteCall",
+		stack:       callers(1),
 	}
 }
 
@@ -229,6 +535,118 @@ func newRemoteCallDescription(resource string) string {
 	return desc
 }
 
+// NewTimeout creates an Error for operations exceeding their deadline
+//
+// (e.g. database query took too long, sync inter-service transaction timed out)
+func NewTimeout(externalResource string, duration time.Duration) Error {
+	return Error{
+		parent:      nil,
+		group:       infrastructure,
+		kind:        timeout,
+		property:    externalResource,
+		title:       "Deadline exceeded",
+		description: newTimeoutDescription(externalResource, duration),
+		statusName:  "DeadlineExceeded",
+		duration:    duration,
+		stack:       callers(1),
+	}
+}
+
+func newTimeoutDescription(resource string, duration time.Duration) string {
+	desc := "Timed out"
+	if duration > 0 {
+		desc = desc + " after " + duration.String()
+	}
+	if resource != "" {
+		desc = desc + " while calling external resource [" + resource + "]"
+	}
+	return desc
+}
+
+// NewCanceled creates an Error for operations canceled before completion, usually by the caller
+//
+// (e.g. client closed the connection, context was canceled)
+func NewCanceled(externalResource string) Error {
+	return Error{
+		parent:      nil,
+		group:       infrastructure,
+		kind:        canceled,
+		property:    externalResource,
+		title:       "Operation canceled",
+		description: newCanceledDescription(externalResource),
+		statusName:  "Canceled",
+		stack:       callers(1),
+	}
+}
+
+func newCanceledDescription(resource string) string {
+	desc := "Operation canceled"
+	if resource != "" {
+		desc = desc + " while calling external resource [" + resource + "]"
+	}
+	return desc
+}
+
+// NewResourceExhausted creates an Error for quota and throttling use cases
+//
+// (description e.g. The quota for resource foo was exhausted, limit is 100, retry after 30s)
+func NewResourceExhausted(resource string, limit int, retryAfter time.Duration) Error {
+	return Error{
+		parent:      nil,
+		group:       infrastructure,
+		kind:        resourceExhausted,
+		property:    resource,
+		title:       "Resource exhausted",
+		description: newResourceExhaustedDescription(resource, limit, retryAfter),
+		statusName:  getSanitizedStatusName(resource, "ResourceExhausted"),
+		limitA:      limit,
+		retryAfter:  retryAfter,
+		stack:       callers(1),
+	}
+}
+
+func newResourceExhaustedDescription(resource string, limit int, retryAfter time.Duration) string {
+	desc := "quota exhausted"
+	if resource != "" {
+		desc = "The quota for resource " + resource + " was exhausted"
+	}
+	if limit > 0 {
+		desc = desc + ", limit is " + strconv.Itoa(limit)
+	}
+	if retryAfter > 0 {
+		desc = desc + ", retry after " + retryAfter.String()
+	}
+	return desc
+}
+
+// NewUnavailable creates an Error for dependency outages
+//
+// (e.g. database is down for maintenance, downstream service is overloaded)
+func NewUnavailable(externalResource string, retryAfter time.Duration) Error {
+	return Error{
+		parent:      nil,
+		group:       infrastructure,
+		kind:        unavailable,
+		property:    externalResource,
+		title:       "Service unavailable",
+		description: newUnavailableDescription(externalResource, retryAfter),
+		statusName:  "Unavailable",
+		retryAfter:  retryAfter,
+		stack:       callers(1),
+	}
+}
+
+func newUnavailableDescription(resource string, retryAfter time.Duration) string {
+	desc := "External resource is unavailable"
+	if resource != "" {
+		desc = "External resource [" + resource + "] is unavailable"
+	}
+	if retryAfter > 0 {
+		desc = desc + ", retry after " + retryAfter.String()
+	}
+	return desc
+}
+
 // NewNotFound creates an Error for Not Found use cases
 //
 // (description e.g. The resource foo was not found)
@@ -241,6 +659,7 @@ func NewNotFound(resource string) Error {
 		title:       "Resource not found",
 		description: newNotFoundDescription(resource),
 		statusName:  getSanitizedStatusName(resource, "NotFound"),
+		stack:       callers(1),
 	}
 }
 
@@ -264,6 +683,7 @@ func NewAlreadyExists(resource string) Error {
 		title:       "Resource already exists",
 		description: newAlreadyExistsDescription(resource),
 		statusName:  getSanitizedStatusName(resource, "AlreadyExists"),
+		stack:       callers(1),
 	}
 }
 
@@ -289,6 +709,7 @@ func NewOutOfRange(property string, a, b int) Error {
 		statusName:  getSanitizedStatusName(property, "OutOfRange"),
 		limitA:      a,
 		limitB:      b,
+		stack:       callers(1),
 	}
 }
 
@@ -313,6 +734,7 @@ func NewInvalidFormat(property string, formats ...string) Error {
 		description: newInvalidFormatDescription(property, formats...),
 		statusName:  getSanitizedStatusName(property, "InvalidFormat"),
 		formats:     formats,
+		stack:       callers(1),
 	}
 }
 
@@ -324,6 +746,63 @@ func newInvalidFormatDescription(property string, formats ...string) string {
 	return desc
 }
 
+// NewInvalidLength creates an Error for length and size constraints of strings and collections.
+//
+// If max is zero or negative, then the length has no maximum.
+//
+// (description e.g. The property foo is too short, minimum length is 3, got 2)
+func NewInvalidLength(property string, min, max, actual int) Error {
+	return Error{
+		parent:      nil,
+		group:       domain,
+		kind:        invalidLength,
+		property:    property,
+		title:       "Property has an invalid length",
+		description: newInvalidLengthDescription(property, min, max, actual),
+		statusName:  getSanitizedStatusName(property, getInvalidLengthStatus(min, max, actual)),
+		limitA:      min,
+		limitB:      max,
+		length:      actual,
+		stack:       callers(1),
+	}
+}
+
+func getInvalidLengthStatus(min, max, actual int) string {
+	switch {
+	case actual < min:
+		return tooShort
+	case max > 0 && actual > max:
+		return tooLong
+	default:
+		return invalidLength
+	}
+}
+
+func newInvalidLengthDescription(property string, min, max, actual int) string {
+	verb, desc := "is", ""
+	switch getInvalidLengthStatus(min, max, actual) {
+	case tooShort:
+		desc = "too short, minimum length is " + strconv.Itoa(min)
+	case tooLong:
+		desc = "too long, maximum length is " + strconv.Itoa(max)
+	default:
+		verb, desc = "has an", "invalid length, expected ["+strconv.Itoa(min)+","+formatLengthMax(max)+"]"
+	}
+	desc = desc + ", got " + strconv.Itoa(actual)
+	if property != "" {
+		desc = "The property " + property + " " + verb + " " + desc
+	}
+	return desc
+}
+
+// formats the given maximum length, zero or negative values are rendered as +inf
+func formatLengthMax(max int) string {
+	if max <= 0 {
+		return "+inf"
+	}
+	return strconv.Itoa(max)
+}
+
 // NewRequired creates an Error for Required use cases
 //
 // (description e.g. The property foo is required)
@@ -336,6 +815,7 @@ func NewRequired(property string) Error {
 		title:       "Missing property",
 		description: newRequiredDescription(property),
 		statusName:  getSanitizedStatusName(property, "IsRequired"),
+		stack:       callers(1),
 	}
 }
 
@@ -346,3 +826,161 @@ func newRequiredDescription(property string) string {
 	}
 	return desc
 }
+
+// NewUnauthenticated creates an Error for Unauthenticated use cases (i.e. missing, invalid or expired credentials)
+//
+// (description e.g. The credential foo is missing, invalid or expired)
+func NewUnauthenticated(credential string) Error {
+	return Error{
+		parent:      nil,
+		group:       domain,
+		kind:        unauthenticated,
+		property:    credential,
+		title:       "Unauthenticated",
+		description: newUnauthenticatedDescription(credential),
+		statusName:  getSanitizedStatusName(credential, "Unauthenticated"),
+		stack:       callers(1),
+	}
+}
+
+func newUnauthenticatedDescription(credential string) string {
+	desc := "unauthenticated"
+	if credential != "" {
+		desc = "The credential " + credential + " is missing, invalid or expired"
+	}
+	return desc
+}
+
+// NewPermissionDenied creates an Error for Permission Denied use cases (i.e. the caller is authenticated
+// but is not allowed to perform the action)
+//
+// (description e.g. The permission to delete the resource foo was denied)
+func NewPermissionDenied(resource, action string) Error {
+	return Error{
+		parent:      nil,
+		group:       domain,
+		kind:        permissionDenied,
+		property:    resource,
+		title:       "Permission denied",
+		description: newPermissionDeniedDescription(resource, action),
+		statusName:  getSanitizedStatusName(resource, "PermissionDenied"),
+		action:      action,
+		stack:       callers(1),
+	}
+}
+
+func newPermissionDeniedDescription(resource, action string) string {
+	switch {
+	case resource != "" && action != "":
+		return "The permission to " + action + " the resource " + resource + " was denied"
+	case resource != "":
+		return "The permission on the resource " + resource + " was denied"
+	case action != "":
+		return "The permission to " + action + " was denied"
+	default:
+		return "permission denied"
+	}
+}
+
+// NewConcurrencyConflict creates an Error for optimistic concurrency use cases (i.e. the aggregate version
+// differs from the version expected by the caller)
+//
+// (description e.g. The aggregate foo has a concurrency conflict, expected version 3 but got 4)
+func NewConcurrencyConflict(aggregate string, expectedVersion, actualVersion int64) Error {
+	return Error{
+		parent:          nil,
+		group:           domain,
+		kind:            concurrencyConflict,
+		property:        aggregate,
+		title:           "Concurrency conflict",
+		description:     newConcurrencyConflictDescription(aggregate, expectedVersion, actualVersion),
+		statusName:      getSanitizedStatusName(aggregate, "ConcurrencyConflict"),
+		expectedVersion: expectedVersion,
+		actualVersion:   actualVersion,
+		stack:           callers(1),
+	}
+}
+
+func newConcurrencyConflictDescription(aggregate string, expectedVersion, actualVersion int64) string {
+	desc := "concurrency conflict, expected version " + strconv.FormatInt(expectedVersion, 10) +
+		" but got " + strconv.FormatInt(actualVersion, 10)
+	if aggregate != "" {
+		desc = "The aggregate " + aggregate + " has a " + desc
+	}
+	return desc
+}
+
+// NewFailedPrecondition creates an Error for Failed Precondition use cases (i.e. the system is not in the
+// state required to perform the operation)
+//
+// (description e.g. The precondition for foo failed: foo must be verified)
+func NewFailedPrecondition(property, reason string) Error {
+	return Error{
+		parent:      nil,
+		group:       domain,
+		kind:        failedPrecondition,
+		property:    property,
+		title:       "Precondition failed",
+		description: newFailedPreconditionDescription(property, reason),
+		statusName:  getSanitizedStatusName(property, "FailedPrecondition"),
+		reason:      reason,
+		stack:       callers(1),
+	}
+}
+
+func newFailedPreconditionDescription(property, reason string) string {
+	desc := "precondition failed"
+	if property != "" {
+		desc = "The precondition for " + property + " failed"
+	}
+	if reason != "" {
+		desc = desc + ": " + reason
+	}
+	return desc
+}
+
+// NewInvariantViolation creates an Error for business rule use cases (i.e. an aggregate invariant was violated,
+// like an order being shipped twice).
+//
+// The rule identifier is used verbatim as the Error status, so clients may rely on it.
+//
+// (description e.g. The aggregate order violated the rule ORDER_ALREADY_SHIPPED: an order cannot be shipped twice)
+func NewInvariantViolation(ruleID, aggregate, message string) Error {
+	return Error{
+		parent:      nil,
+		group:       domain,
+		kind:        invariantViolation,
+		property:    aggregate,
+		title:       "Business rule violated",
+		description: newInvariantViolationDescription(ruleID, aggregate, message),
+		statusName:  getInvariantViolationStatus(ruleID, aggregate),
+		ruleID:      ruleID,
+		reason:      message,
+		stack:       callers(1),
+	}
+}
+
+func newInvariantViolationDescription(ruleID, aggregate, message string) string {
+	desc := "invariant violation"
+	if ruleID != "" {
+		desc = "rule " + ruleID + " violated"
+	}
+	if aggregate != "" && ruleID != "" {
+		desc = "The aggregate " + aggregate + " violated the rule " + ruleID
+	} else if aggregate != "" {
+		desc = "The aggregate " + aggregate + " violated an invariant"
+	}
+	if message != "" {
+		desc = desc + ": " + message
+	}
+	return desc
+}
+
+// retrieves the rule identifier as status name, falls back to a generic InvariantViolation status name if
+// rule identifier is missing
+func getInvariantViolationStatus(ruleID, aggregate string) string {
+	if ruleID != "" {
+		return ruleID
+	}
+	return getSanitizedStatusName(aggregate, invariantViolation)
+}
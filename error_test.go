@@ -2,7 +2,9 @@ package ddderr
 
 import (
 	"errors"
+	"fmt"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -31,6 +33,216 @@ func TestError_Setters(t *testing.T) {
 
 }
 
+type mockDriverError struct {
+	code string
+}
+
+func (e mockDriverError) Error() string {
+	return "driver: error code " + e.code
+}
+
+func TestError_Unwrap(t *testing.T) {
+	rootErr := errors.New("sql: no rows in result set")
+	err := NewNotFound("foo").SetParent(rootErr)
+	assert.Equal(t, rootErr, errors.Unwrap(err))
+	assert.True(t, errors.Is(err, rootErr))
+	assert.Nil(t, errors.Unwrap(NewNotFound("foo")))
+
+	// mixed fmt.Errorf and ddderr layers
+	driverErr := mockDriverError{code: "23505"}
+	chain := fmt.Errorf("user repository: %w",
+		NewAlreadyExists("user").SetParent(fmt.Errorf("insert row: %w", driverErr)))
+	assert.True(t, errors.Is(chain, driverErr))
+	assert.False(t, errors.Is(chain, rootErr))
+
+	var dErr mockDriverError
+	assert.True(t, errors.As(chain, &dErr))
+	assert.Equal(t, "23505", dErr.code)
+
+	var customErr Error
+	assert.True(t, errors.As(chain, &customErr))
+	assert.True(t, customErr.IsAlreadyExists())
+	assert.Equal(t, "user", customErr.Property())
+
+	// nested ddderr layers
+	chain = fmt.Errorf("get user: %w", NewRemoteCall("localhost:5432").
+		SetParent(NewInfrastructure("db failed", "failed to query database").
+			SetParent(rootErr)))
+	assert.True(t, errors.Is(chain, rootErr))
+	assert.True(t, errors.As(chain, &customErr))
+	assert.True(t, customErr.IsRemoteCall())
+	assert.True(t, errors.As(customErr.Unwrap(), &customErr))
+	assert.Equal(t, "failed to query database", customErr.Description())
+}
+
+var sentinelTestSuite = []struct {
+	InErr     error
+	InTarget  error
+	ExpResult bool
+}{
+	{
+		InErr:     NewNotFound("foo"),
+		InTarget:  ErrNotFound,
+		ExpResult: true,
+	},
+	{
+		InErr:     NewNotFound("bar"),
+		InTarget:  ErrNotFound,
+		ExpResult: true,
+	},
+	{
+		InErr:     fmt.Errorf("get user: %w", fmt.Errorf("repository: %w", NewNotFound("user"))),
+		InTarget:  ErrNotFound,
+		ExpResult: true,
+	},
+	{
+		InErr:     NewNotFound("foo"),
+		InTarget:  ErrAlreadyExists,
+		ExpResult: false,
+	},
+	{
+		InErr:     NewNotFound("foo"),
+		InTarget:  ErrDomain,
+		ExpResult: true,
+	},
+	{
+		InErr:     NewNotFound("foo"),
+		InTarget:  ErrInfrastructure,
+		ExpResult: false,
+	},
+	{
+		InErr:     NewNotFound("foo"),
+		InTarget:  NewNotFound("foo"),
+		ExpResult: false,
+	},
+	{
+		InErr:     NewAlreadyExists("foo"),
+		InTarget:  ErrAlreadyExists,
+		ExpResult: true,
+	},
+	{
+		InErr:     NewOutOfRange("foo", 1, 2),
+		InTarget:  ErrOutOfRange,
+		ExpResult: true,
+	},
+	{
+		InErr:     NewInvalidFormat("foo", "bar"),
+		InTarget:  ErrInvalidFormat,
+		ExpResult: true,
+	},
+	{
+		InErr:     NewRequired("foo"),
+		InTarget:  ErrRequired,
+		ExpResult: true,
+	},
+	{
+		InErr:     NewInvalidLength("foo", 3, 20, 2),
+		InTarget:  ErrInvalidLength,
+		ExpResult: true,
+	},
+	{
+		InErr:     NewInvalidLength("foo", 3, 20, 2),
+		InTarget:  ErrOutOfRange,
+		ExpResult: false,
+	},
+	{
+		InErr:     NewRemoteCall("foo.com"),
+		InTarget:  ErrRemoteCall,
+		ExpResult: true,
+	},
+	{
+		InErr:     NewRemoteCall("foo.com"),
+		InTarget:  ErrInfrastructure,
+		ExpResult: true,
+	},
+	{
+		InErr:     NewInfrastructure("generic title", "specific description"),
+		InTarget:  ErrInfrastructure,
+		ExpResult: true,
+	},
+	{
+		InErr:     NewInfrastructure("generic title", "specific description"),
+		InTarget:  ErrRemoteCall,
+		ExpResult: false,
+	},
+	{
+		InErr:     NewDomain("generic title", "specific description"),
+		InTarget:  ErrDomain,
+		ExpResult: true,
+	},
+	{
+		InErr:     NewInfrastructure("", "").SetParent(NewRequired("foo")),
+		InTarget:  ErrRequired,
+		ExpResult: true,
+	},
+	{
+		InErr:     errors.New("not found"),
+		InTarget:  ErrNotFound,
+		ExpResult: false,
+	},
+	{
+		InErr:     fmt.Errorf("authenticate: %w", NewUnauthenticated("access_token")),
+		InTarget:  ErrUnauthenticated,
+		ExpResult: true,
+	},
+	{
+		InErr:     NewPermissionDenied("foo", "delete"),
+		InTarget:  ErrPermissionDenied,
+		ExpResult: true,
+	},
+	{
+		InErr:     NewPermissionDenied("foo", "delete"),
+		InTarget:  ErrUnauthenticated,
+		ExpResult: false,
+	},
+	{
+		InErr:     fmt.Errorf("save order: %w", NewConcurrencyConflict("order", 3, 4)),
+		InTarget:  ErrConcurrencyConflict,
+		ExpResult: true,
+	},
+	{
+		InErr:     NewConcurrencyConflict("order", 3, 4),
+		InTarget:  ErrAlreadyExists,
+		ExpResult: false,
+	},
+	{
+		InErr:     NewFailedPrecondition("order", "order must be paid"),
+		InTarget:  ErrFailedPrecondition,
+		ExpResult: true,
+	},
+	{
+		InErr:     NewInvariantViolation("ORDER_ALREADY_SHIPPED", "order", "an order cannot be shipped twice"),
+		InTarget:  ErrInvariantViolation,
+		ExpResult: true,
+	},
+	{
+		InErr:     NewInvariantViolation("ORDER_ALREADY_SHIPPED", "order", "an order cannot be shipped twice"),
+		InTarget:  ErrFailedPrecondition,
+		ExpResult: false,
+	},
+	{
+		InErr:     NewResourceExhausted("api_calls", 100, time.Second),
+		InTarget:  ErrResourceExhausted,
+		ExpResult: true,
+	},
+	{
+		InErr:     fmt.Errorf("query: %w", NewUnavailable("localhost:5432", time.Second)),
+		InTarget:  ErrUnavailable,
+		ExpResult: true,
+	},
+}
+
+func TestError_Is(t *testing.T) {
+	for _, tt := range sentinelTestSuite {
+		t.Run("", func(t *testing.T) {
+			assert.Equal(t, tt.ExpResult, errors.Is(tt.InErr, tt.InTarget))
+		})
+	}
+	assert.Equal(t, "not found", ErrNotFound.Error())
+	assert.True(t, ErrNotFound.IsNotFound())
+	assert.True(t, ErrRemoteCall.IsInfrastructure())
+}
+
 var dynamicFieldTests = []struct {
 	In             Error
 	InDynamicField string
@@ -81,6 +293,78 @@ var dynamicFieldTests = []struct {
 		ExpDesc:        "The property foo is required",
 		ExpStatus:      "FooRequired",
 	},
+	{
+		In:             NewUnauthenticated("bar"),
+		InDynamicField: "foo",
+		ExpDesc:        "The credential foo is missing, invalid or expired",
+		ExpStatus:      "FooUnauthenticated",
+	},
+	{
+		In:             NewPermissionDenied("bar", "delete"),
+		InDynamicField: "foo",
+		ExpDesc:        "The permission to delete the resource foo was denied",
+		ExpStatus:      "FooPermissionDenied",
+	},
+	{
+		In:             NewConcurrencyConflict("bar", 3, 4),
+		InDynamicField: "foo",
+		ExpDesc:        "The aggregate foo has a concurrency conflict, expected version 3 but got 4",
+		ExpStatus:      "FooConcurrencyConflict",
+	},
+	{
+		In:             NewFailedPrecondition("bar", "must be verified"),
+		InDynamicField: "foo",
+		ExpDesc:        "The precondition for foo failed: must be verified",
+		ExpStatus:      "FooFailedPrecondition",
+	},
+	{
+		In:             NewInvariantViolation("CART_MAX_ITEMS", "bar", "a cart may not exceed 50 items"),
+		InDynamicField: "foo",
+		ExpDesc:        "The aggregate foo violated the rule CART_MAX_ITEMS: a cart may not exceed 50 items",
+		ExpStatus:      "CART_MAX_ITEMS",
+	},
+	{
+		In:             NewInvariantViolation("", "bar", ""),
+		InDynamicField: "foo",
+		ExpDesc:        "The aggregate foo violated an invariant",
+		ExpStatus:      "FooInvariantViolation",
+	},
+	{
+		In:             NewTimeout("bar.com", time.Second),
+		InDynamicField: "foo.org",
+		ExpDesc:        "Timed out after 1s while calling external resource [foo.org]",
+		ExpStatus:      "FooOrgDeadlineExceeded",
+	},
+	{
+		In:             NewCanceled("bar.com"),
+		InDynamicField: "foo.org",
+		ExpDesc:        "Operation canceled while calling external resource [foo.org]",
+		ExpStatus:      "FooOrgCanceled",
+	},
+	{
+		In:             NewResourceExhausted("bar", 10, time.Second),
+		InDynamicField: "foo",
+		ExpDesc:        "The quota for resource foo was exhausted, limit is 10, retry after 1s",
+		ExpStatus:      "FooResourceExhausted",
+	},
+	{
+		In:             NewUnavailable("bar.com", 0),
+		InDynamicField: "foo.org",
+		ExpDesc:        "External resource [foo.org] is unavailable",
+		ExpStatus:      "FooOrgUnavailable",
+	},
+	{
+		In:             NewInvalidLength("bar", 3, 20, 2),
+		InDynamicField: "foo",
+		ExpDesc:        "The property foo is too short, minimum length is 3, got 2",
+		ExpStatus:      "FooTooShort",
+	},
+	{
+		In:             NewInvalidLength("bar", 3, 20, 21),
+		InDynamicField: "foo",
+		ExpDesc:        "The property foo is too long, maximum length is 20, got 21",
+		ExpStatus:      "FooTooLong",
+	},
 	{
 		In:             Error{},
 		InDynamicField: "",
@@ -569,3 +853,739 @@ func TestNewRequired(t *testing.T) {
 		})
 	}
 }
+
+var newUnauthenticatedTestSuite = []struct {
+	InCredential string
+	Exp          Error
+}{
+	{
+		InCredential: "",
+		Exp: Error{
+			parent:      nil,
+			group:       domain,
+			kind:        unauthenticated,
+			property:    "",
+			title:       "Unauthenticated",
+			description: "unauthenticated",
+			statusName:  "Unauthenticated",
+		},
+	},
+	{
+		InCredential: "access_token",
+		Exp: Error{
+			parent:      nil,
+			group:       domain,
+			kind:        unauthenticated,
+			property:    "access_token",
+			title:       "Unauthenticated",
+			description: "The credential access_token is missing, invalid or expired",
+			statusName:  "AccessTokenUnauthenticated",
+		},
+	},
+}
+
+func TestNewUnauthenticated(t *testing.T) {
+	for _, tt := range newUnauthenticatedTestSuite {
+		t.Run("", func(t *testing.T) {
+			err := NewUnauthenticated(tt.InCredential)
+			assert.EqualValues(t, tt.Exp, err)
+			assert.Equal(t, tt.Exp.Title(), err.Title())
+			assert.Equal(t, tt.Exp.Description(), err.Description())
+			assert.Equal(t, tt.Exp.Status(), err.Status())
+			assert.Equal(t, tt.Exp.Property(), err.Property())
+			assert.True(t, err.IsUnauthenticated())
+			assert.True(t, err.IsDomain())
+			assert.False(t, err.IsInfrastructure())
+			assert.False(t, err.IsPermissionDenied())
+			assert.False(t, err.IsNotFound())
+			assert.Empty(t, err.Challenge())
+
+			err = err.SetChallenge(`Bearer realm="example"`)
+			assert.Equal(t, `Bearer realm="example"`, err.Challenge())
+		})
+	}
+}
+
+var newPermissionDeniedTestSuite = []struct {
+	InResource string
+	InAction   string
+	Exp        Error
+}{
+	{
+		InResource: "",
+		InAction:   "",
+		Exp: Error{
+			parent:      nil,
+			group:       domain,
+			kind:        permissionDenied,
+			property:    "",
+			title:       "Permission denied",
+			description: "permission denied",
+			statusName:  "PermissionDenied",
+		},
+	},
+	{
+		InResource: "",
+		InAction:   "delete",
+		Exp: Error{
+			parent:      nil,
+			group:       domain,
+			kind:        permissionDenied,
+			property:    "",
+			title:       "Permission denied",
+			description: "The permission to delete was denied",
+			statusName:  "PermissionDenied",
+			action:      "delete",
+		},
+	},
+	{
+		InResource: "foo",
+		InAction:   "",
+		Exp: Error{
+			parent:      nil,
+			group:       domain,
+			kind:        permissionDenied,
+			property:    "foo",
+			title:       "Permission denied",
+			description: "The permission on the resource foo was denied",
+			statusName:  "FooPermissionDenied",
+		},
+	},
+	{
+		InResource: "foo",
+		InAction:   "delete",
+		Exp: Error{
+			parent:      nil,
+			group:       domain,
+			kind:        permissionDenied,
+			property:    "foo",
+			title:       "Permission denied",
+			description: "The permission to delete the resource foo was denied",
+			statusName:  "FooPermissionDenied",
+			action:      "delete",
+		},
+	},
+}
+
+func TestNewPermissionDenied(t *testing.T) {
+	for _, tt := range newPermissionDeniedTestSuite {
+		t.Run("", func(t *testing.T) {
+			err := NewPermissionDenied(tt.InResource, tt.InAction)
+			assert.EqualValues(t, tt.Exp, err)
+			assert.Equal(t, tt.Exp.Title(), err.Title())
+			assert.Equal(t, tt.Exp.Description(), err.Description())
+			assert.Equal(t, tt.Exp.Status(), err.Status())
+			assert.Equal(t, tt.Exp.Property(), err.Property())
+			assert.Equal(t, tt.InAction, err.Action())
+			assert.True(t, err.IsPermissionDenied())
+			assert.True(t, err.IsDomain())
+			assert.False(t, err.IsInfrastructure())
+			assert.False(t, err.IsUnauthenticated())
+			assert.False(t, err.IsNotFound())
+		})
+	}
+}
+
+var newConcurrencyConflictTestSuite = []struct {
+	InAggregate string
+	InExpected  int64
+	InActual    int64
+	Exp         Error
+}{
+	{
+		InAggregate: "",
+		InExpected:  0,
+		InActual:    1,
+		Exp: Error{
+			parent:          nil,
+			group:           domain,
+			kind:            concurrencyConflict,
+			property:        "",
+			title:           "Concurrency conflict",
+			description:     "concurrency conflict, expected version 0 but got 1",
+			statusName:      "ConcurrencyConflict",
+			expectedVersion: 0,
+			actualVersion:   1,
+		},
+	},
+	{
+		InAggregate: "order",
+		InExpected:  3,
+		InActual:    4,
+		Exp: Error{
+			parent:          nil,
+			group:           domain,
+			kind:            concurrencyConflict,
+			property:        "order",
+			title:           "Concurrency conflict",
+			description:     "The aggregate order has a concurrency conflict, expected version 3 but got 4",
+			statusName:      "OrderConcurrencyConflict",
+			expectedVersion: 3,
+			actualVersion:   4,
+		},
+	},
+}
+
+func TestNewConcurrencyConflict(t *testing.T) {
+	for _, tt := range newConcurrencyConflictTestSuite {
+		t.Run("", func(t *testing.T) {
+			err := NewConcurrencyConflict(tt.InAggregate, tt.InExpected, tt.InActual)
+			assert.EqualValues(t, tt.Exp, err)
+			assert.Equal(t, tt.Exp.Title(), err.Title())
+			assert.Equal(t, tt.Exp.Description(), err.Description())
+			assert.Equal(t, tt.Exp.Status(), err.Status())
+			assert.Equal(t, tt.InAggregate, err.Property())
+			assert.Equal(t, tt.InExpected, err.ExpectedVersion())
+			assert.Equal(t, tt.InActual, err.ActualVersion())
+			assert.True(t, err.IsConcurrencyConflict())
+			assert.True(t, err.IsDomain())
+			assert.False(t, err.IsInfrastructure())
+			assert.False(t, err.IsAlreadyExists())
+			assert.False(t, err.IsFailedPrecondition())
+		})
+	}
+}
+
+var newFailedPreconditionTestSuite = []struct {
+	InProp   string
+	InReason string
+	Exp      Error
+}{
+	{
+		InProp:   "",
+		InReason: "",
+		Exp: Error{
+			parent:      nil,
+			group:       domain,
+			kind:        failedPrecondition,
+			property:    "",
+			title:       "Precondition failed",
+			description: "precondition failed",
+			statusName:  "FailedPrecondition",
+		},
+	},
+	{
+		InProp:   "",
+		InReason: "order must be paid",
+		Exp: Error{
+			parent:      nil,
+			group:       domain,
+			kind:        failedPrecondition,
+			property:    "",
+			title:       "Precondition failed",
+			description: "precondition failed: order must be paid",
+			statusName:  "FailedPrecondition",
+			reason:      "order must be paid",
+		},
+	},
+	{
+		InProp:   "order",
+		InReason: "",
+		Exp: Error{
+			parent:      nil,
+			group:       domain,
+			kind:        failedPrecondition,
+			property:    "order",
+			title:       "Precondition failed",
+			description: "The precondition for order failed",
+			statusName:  "OrderFailedPrecondition",
+		},
+	},
+	{
+		InProp:   "order",
+		InReason: "order must be paid",
+		Exp: Error{
+			parent:      nil,
+			group:       domain,
+			kind:        failedPrecondition,
+			property:    "order",
+			title:       "Precondition failed",
+			description: "The precondition for order failed: order must be paid",
+			statusName:  "OrderFailedPrecondition",
+			reason:      "order must be paid",
+		},
+	},
+}
+
+func TestNewFailedPrecondition(t *testing.T) {
+	for _, tt := range newFailedPreconditionTestSuite {
+		t.Run("", func(t *testing.T) {
+			err := NewFailedPrecondition(tt.InProp, tt.InReason)
+			assert.EqualValues(t, tt.Exp, err)
+			assert.Equal(t, tt.Exp.Title(), err.Title())
+			assert.Equal(t, tt.Exp.Description(), err.Description())
+			assert.Equal(t, tt.Exp.Status(), err.Status())
+			assert.Equal(t, tt.InProp, err.Property())
+			assert.Equal(t, tt.InReason, err.Reason())
+			assert.True(t, err.IsFailedPrecondition())
+			assert.True(t, err.IsDomain())
+			assert.False(t, err.IsInfrastructure())
+			assert.False(t, err.IsConcurrencyConflict())
+		})
+	}
+}
+
+var newInvariantViolationTestSuite = []struct {
+	InRuleID    string
+	InAggregate string
+	InMessage   string
+	Exp         Error
+}{
+	{
+		InRuleID:    "",
+		InAggregate: "",
+		InMessage:   "",
+		Exp: Error{
+			parent:      nil,
+			group:       domain,
+			kind:        invariantViolation,
+			property:    "",
+			title:       "Business rule violated",
+			description: "invariant violation",
+			statusName:  "InvariantViolation",
+		},
+	},
+	{
+		InRuleID:    "ORDER_ALREADY_SHIPPED",
+		InAggregate: "",
+		InMessage:   "",
+		Exp: Error{
+			parent:      nil,
+			group:       domain,
+			kind:        invariantViolation,
+			property:    "",
+			title:       "Business rule violated",
+			description: "rule ORDER_ALREADY_SHIPPED violated",
+			statusName:  "ORDER_ALREADY_SHIPPED",
+			ruleID:      "ORDER_ALREADY_SHIPPED",
+		},
+	},
+	{
+		InRuleID:    "",
+		InAggregate: "order",
+		InMessage:   "an order cannot be shipped twice",
+		Exp: Error{
+			parent:      nil,
+			group:       domain,
+			kind:        invariantViolation,
+			property:    "order",
+			title:       "Business rule violated",
+			description: "The aggregate order violated an invariant: an order cannot be shipped twice",
+			statusName:  "OrderInvariantViolation",
+			reason:      "an order cannot be shipped twice",
+		},
+	},
+	{
+		InRuleID:    "ORDER_ALREADY_SHIPPED",
+		InAggregate: "order",
+		InMessage:   "an order cannot be shipped twice",
+		Exp: Error{
+			parent:      nil,
+			group:       domain,
+			kind:        invariantViolation,
+			property:    "order",
+			title:       "Business rule violated",
+			description: "The aggregate order violated the rule ORDER_ALREADY_SHIPPED: an order cannot be shipped twice",
+			statusName:  "ORDER_ALREADY_SHIPPED",
+			ruleID:      "ORDER_ALREADY_SHIPPED",
+			reason:      "an order cannot be shipped twice",
+		},
+	},
+}
+
+func TestNewInvariantViolation(t *testing.T) {
+	for _, tt := range newInvariantViolationTestSuite {
+		t.Run("", func(t *testing.T) {
+			err := NewInvariantViolation(tt.InRuleID, tt.InAggregate, tt.InMessage)
+			assert.EqualValues(t, tt.Exp, err)
+			assert.Equal(t, tt.Exp.Title(), err.Title())
+			assert.Equal(t, tt.Exp.Description(), err.Description())
+			assert.Equal(t, tt.Exp.Status(), err.Status())
+			assert.Equal(t, tt.InAggregate, err.Property())
+			assert.Equal(t, tt.InRuleID, err.RuleID())
+			assert.Equal(t, tt.InMessage, err.Reason())
+			assert.True(t, err.IsInvariantViolation())
+			assert.True(t, err.IsDomain())
+			assert.False(t, err.IsInfrastructure())
+			assert.False(t, err.IsFailedPrecondition())
+		})
+	}
+}
+
+var newTimeoutTestSuite = []struct {
+	InExternalResource string
+	InDuration         time.Duration
+	Exp                Error
+}{
+	{
+		InExternalResource: "",
+		InDuration:         0,
+		Exp: Error{
+			parent:      nil,
+			group:       infrastructure,
+			kind:        timeout,
+			property:    "",
+			title:       "Deadline exceeded",
+			description: "Timed out",
+			statusName:  "DeadlineExceeded",
+		},
+	},
+	{
+		InExternalResource: "",
+		InDuration:         time.Second * 5,
+		Exp: Error{
+			parent:      nil,
+			group:       infrastructure,
+			kind:        timeout,
+			property:    "",
+			title:       "Deadline exceeded",
+			description: "Timed out after 5s",
+			statusName:  "DeadlineExceeded",
+			duration:    time.Second * 5,
+		},
+	},
+	{
+		InExternalResource: "https://foo.com",
+		InDuration:         time.Millisecond * 250,
+		Exp: Error{
+			parent:      nil,
+			group:       infrastructure,
+			kind:        timeout,
+			property:    "https://foo.com",
+			title:       "Deadline exceeded",
+			description: "Timed out after 250ms while calling external resource [https://foo.com]",
+			statusName:  "DeadlineExceeded",
+			duration:    time.Millisecond * 250,
+		},
+	},
+}
+
+func TestNewTimeout(t *testing.T) {
+	for _, tt := range newTimeoutTestSuite {
+		t.Run("", func(t *testing.T) {
+			err := NewTimeout(tt.InExternalResource, tt.InDuration)
+			assert.EqualValues(t, tt.Exp, err)
+			assert.Equal(t, tt.Exp.Title(), err.Title())
+			assert.Equal(t, tt.Exp.Description(), err.Description())
+			assert.Equal(t, tt.Exp.Status(), err.Status())
+			assert.Equal(t, tt.InDuration, err.Duration())
+			assert.True(t, err.IsTimeout())
+			assert.True(t, err.IsInfrastructure())
+			assert.False(t, err.IsDomain())
+			assert.False(t, err.IsRemoteCall())
+			assert.False(t, err.IsCanceled())
+		})
+	}
+}
+
+var newCanceledTestSuite = []struct {
+	InExternalResource string
+	Exp                Error
+}{
+	{
+		InExternalResource: "",
+		Exp: Error{
+			parent:      nil,
+			group:       infrastructure,
+			kind:        canceled,
+			property:    "",
+			title:       "Operation canceled",
+			description: "Operation canceled",
+			statusName:  "Canceled",
+		},
+	},
+	{
+		InExternalResource: "https://foo.com",
+		Exp: Error{
+			parent:      nil,
+			group:       infrastructure,
+			kind:        canceled,
+			property:    "https://foo.com",
+			title:       "Operation canceled",
+			description: "Operation canceled while calling external resource [https://foo.com]",
+			statusName:  "Canceled",
+		},
+	},
+}
+
+func TestNewCanceled(t *testing.T) {
+	for _, tt := range newCanceledTestSuite {
+		t.Run("", func(t *testing.T) {
+			err := NewCanceled(tt.InExternalResource)
+			assert.EqualValues(t, tt.Exp, err)
+			assert.Equal(t, tt.Exp.Title(), err.Title())
+			assert.Equal(t, tt.Exp.Description(), err.Description())
+			assert.Equal(t, tt.Exp.Status(), err.Status())
+			assert.True(t, err.IsCanceled())
+			assert.True(t, err.IsInfrastructure())
+			assert.False(t, err.IsDomain())
+			assert.False(t, err.IsTimeout())
+		})
+	}
+}
+
+var newResourceExhaustedTestSuite = []struct {
+	InResource   string
+	InLimit      int
+	InRetryAfter time.Duration
+	Exp          Error
+}{
+	{
+		InResource:   "",
+		InLimit:      0,
+		InRetryAfter: 0,
+		Exp: Error{
+			parent:      nil,
+			group:       infrastructure,
+			kind:        resourceExhausted,
+			property:    "",
+			title:       "Resource exhausted",
+			description: "quota exhausted",
+			statusName:  "ResourceExhausted",
+		},
+	},
+	{
+		InResource:   "api_calls",
+		InLimit:      100,
+		InRetryAfter: 0,
+		Exp: Error{
+			parent:      nil,
+			group:       infrastructure,
+			kind:        resourceExhausted,
+			property:    "api_calls",
+			title:       "Resource exhausted",
+			description: "The quota for resource api_calls was exhausted, limit is 100",
+			statusName:  "ApiCallsResourceExhausted",
+			limitA:      100,
+		},
+	},
+	{
+		InResource:   "api_calls",
+		InLimit:      100,
+		InRetryAfter: time.Second * 30,
+		Exp: Error{
+			parent:      nil,
+			group:       infrastructure,
+			kind:        resourceExhausted,
+			property:    "api_calls",
+			title:       "Resource exhausted",
+			description: "The quota for resource api_calls was exhausted, limit is 100, retry after 30s",
+			statusName:  "ApiCallsResourceExhausted",
+			limitA:      100,
+			retryAfter:  time.Second * 30,
+		},
+	},
+}
+
+func TestNewResourceExhausted(t *testing.T) {
+	for _, tt := range newResourceExhaustedTestSuite {
+		t.Run("", func(t *testing.T) {
+			err := NewResourceExhausted(tt.InResource, tt.InLimit, tt.InRetryAfter)
+			assert.EqualValues(t, tt.Exp, err)
+			assert.Equal(t, tt.Exp.Title(), err.Title())
+			assert.Equal(t, tt.Exp.Description(), err.Description())
+			assert.Equal(t, tt.Exp.Status(), err.Status())
+			assert.Equal(t, tt.InLimit, err.Limit())
+			assert.Equal(t, tt.InRetryAfter, err.RetryAfter())
+			assert.True(t, err.IsResourceExhausted())
+			assert.True(t, err.IsInfrastructure())
+			assert.False(t, err.IsDomain())
+			assert.False(t, err.IsUnavailable())
+		})
+	}
+}
+
+var newUnavailableTestSuite = []struct {
+	InExternalResource string
+	InRetryAfter       time.Duration
+	Exp                Error
+}{
+	{
+		InExternalResource: "",
+		InRetryAfter:       0,
+		Exp: Error{
+			parent:      nil,
+			group:       infrastructure,
+			kind:        unavailable,
+			property:    "",
+			title:       "Service unavailable",
+			description: "External resource is unavailable",
+			statusName:  "Unavailable",
+		},
+	},
+	{
+		InExternalResource: "https://foo.com",
+		InRetryAfter:       time.Minute,
+		Exp: Error{
+			parent:      nil,
+			group:       infrastructure,
+			kind:        unavailable,
+			property:    "https://foo.com",
+			title:       "Service unavailable",
+			description: "External resource [https://foo.com] is unavailable, retry after 1m0s",
+			statusName:  "Unavailable",
+			retryAfter:  time.Minute,
+		},
+	},
+}
+
+func TestNewUnavailable(t *testing.T) {
+	for _, tt := range newUnavailableTestSuite {
+		t.Run("", func(t *testing.T) {
+			err := NewUnavailable(tt.InExternalResource, tt.InRetryAfter)
+			assert.EqualValues(t, tt.Exp, err)
+			assert.Equal(t, tt.Exp.Title(), err.Title())
+			assert.Equal(t, tt.Exp.Description(), err.Description())
+			assert.Equal(t, tt.Exp.Status(), err.Status())
+			assert.Equal(t, tt.InRetryAfter, err.RetryAfter())
+			assert.True(t, err.IsUnavailable())
+			assert.True(t, err.IsInfrastructure())
+			assert.False(t, err.IsDomain())
+			assert.False(t, err.IsRemoteCall())
+			assert.False(t, err.IsResourceExhausted())
+		})
+	}
+}
+
+var newInvalidLengthTestSuite = []struct {
+	InProp      string
+	InMin       int
+	InMax       int
+	InActual    int
+	ExpTooShort bool
+	ExpTooLong  bool
+	Exp         Error
+}{
+	{
+		InProp:      "",
+		InMin:       3,
+		InMax:       20,
+		InActual:    2,
+		ExpTooShort: true,
+		Exp: Error{
+			parent:      nil,
+			group:       domain,
+			kind:        invalidLength,
+			property:    "",
+			title:       "Property has an invalid length",
+			description: "too short, minimum length is 3, got 2",
+			statusName:  "TooShort",
+			limitA:      3,
+			limitB:      20,
+			length:      2,
+		},
+	},
+	{
+		InProp:      "username",
+		InMin:       3,
+		InMax:       20,
+		InActual:    2,
+		ExpTooShort: true,
+		Exp: Error{
+			parent:      nil,
+			group:       domain,
+			kind:        invalidLength,
+			property:    "username",
+			title:       "Property has an invalid length",
+			description: "The property username is too short, minimum length is 3, got 2",
+			statusName:  "UsernameTooShort",
+			limitA:      3,
+			limitB:      20,
+			length:      2,
+		},
+	},
+	{
+		InProp:     "username",
+		InMin:      3,
+		InMax:      20,
+		InActual:   21,
+		ExpTooLong: true,
+		Exp: Error{
+			parent:      nil,
+			group:       domain,
+			kind:        invalidLength,
+			property:    "username",
+			title:       "Property has an invalid length",
+			description: "The property username is too long, maximum length is 20, got 21",
+			statusName:  "UsernameTooLong",
+			limitA:      3,
+			limitB:      20,
+			length:      21,
+		},
+	},
+	{
+		InProp:   "items",
+		InMin:    1,
+		InMax:    0,
+		InActual: 500,
+		Exp: Error{
+			parent:      nil,
+			group:       domain,
+			kind:        invalidLength,
+			property:    "items",
+			title:       "Property has an invalid length",
+			description: "The property items has an invalid length, expected [1,+inf], got 500",
+			statusName:  "ItemsInvalidLength",
+			limitA:      1,
+			limitB:      0,
+			length:      500,
+		},
+	},
+	{
+		InProp:   "name",
+		InMin:    3,
+		InMax:    20,
+		InActual: 5,
+		Exp: Error{
+			parent:      nil,
+			group:       domain,
+			kind:        invalidLength,
+			property:    "name",
+			title:       "Property has an invalid length",
+			description: "The property name has an invalid length, expected [3,20], got 5",
+			statusName:  "NameInvalidLength",
+			limitA:      3,
+			limitB:      20,
+			length:      5,
+		},
+	},
+	{
+		InProp:   "",
+		InMin:    3,
+		InMax:    -1,
+		InActual: 5,
+		Exp: Error{
+			parent:      nil,
+			group:       domain,
+			kind:        invalidLength,
+			property:    "",
+			title:       "Property has an invalid length",
+			description: "invalid length, expected [3,+inf], got 5",
+			statusName:  "InvalidLength",
+			limitA:      3,
+			limitB:      -1,
+			length:      5,
+		},
+	},
+}
+
+func TestNewInvalidLength(t *testing.T) {
+	for _, tt := range newInvalidLengthTestSuite {
+		t.Run("", func(t *testing.T) {
+			err := NewInvalidLength(tt.InProp, tt.InMin, tt.InMax, tt.InActual)
+			assert.EqualValues(t, tt.Exp, err)
+			assert.Equal(t, tt.Exp.Title(), err.Title())
+			assert.Equal(t, tt.Exp.Description(), err.Description())
+			assert.Equal(t, tt.Exp.Status(), err.Status())
+			assert.Equal(t, tt.InMin, err.MinLength())
+			assert.Equal(t, tt.InMax, err.MaxLength())
+			assert.Equal(t, tt.InActual, err.Length())
+			assert.Equal(t, tt.ExpTooShort, err.IsTooShort())
+			assert.Equal(t, tt.ExpTooLong, err.IsTooLong())
+			assert.True(t, err.IsInvalidLength())
+			assert.True(t, err.IsDomain())
+			assert.False(t, err.IsInfrastructure())
+			assert.False(t, err.IsOutOfRange())
+		})
+	}
+}
@@ -0,0 +1,134 @@
+package ddderr
+
+import (
+	"errors"
+	"strings"
+)
+
+const (
+	validationTitle  = "One or more properties are invalid"
+	validationStatus = "InvalidProperties"
+)
+
+// Errors is a collection of DDD errors useful to report multiple property failures at once
+// (e.g. value object, entity or aggregate validations).
+//
+// Errors belongs to Domain error group
+type Errors []Error
+
+var _ error = Errors{}
+
+// Append adds the given errors to the collection.
+//
+// Nil errors are ignored and nested Errors are flattened. If an error does not contain a DDD error within its
+// chain, then it is wrapped into a generic Domain error
+func (e Errors) Append(errs ...error) Errors {
+	for _, err := range errs {
+		if err == nil {
+			continue
+		}
+
+		target, _ := asGrouped(err)
+		switch t := target.(type) {
+		case Errors:
+			e = append(e, t...)
+		case Error:
+			e = append(e, t)
+		default:
+			e = append(e, NewDomain(validationTitle, err.Error()).SetParent(err))
+		}
+	}
+	return e
+}
+
+// ErrorOrNil returns nil if the collection is empty, otherwise it returns the collection itself
+func (e Errors) ErrorOrNil() error {
+	if len(e) == 0 {
+		return nil
+	}
+	return e
+}
+
+// Error returns every error description of the collection
+func (e Errors) Error() string {
+	return e.Description()
+}
+
+// Unwrap returns the errors of the collection, this allows the usage of errors.Is and errors.As
+// against every error of the collection on Go 1.20 and later
+func (e Errors) Unwrap() []error {
+	errs := make([]error, 0, len(e))
+	for _, err := range e {
+		errs = append(errs, err)
+	}
+	return errs
+}
+
+// Is reports whether the collection or any of its errors matches the given target.
+//
+// The collection itself only matches the ErrDomain sentinel, every error of the collection is matched
+// explicitly so errors.Is behaves the same on Go versions without multiple error unwrapping (prior to 1.20)
+func (e Errors) Is(target error) bool {
+	if t, ok := target.(Error); ok && t.sentinel && t.kind == "" && t.group == domain {
+		return true
+	}
+	for _, err := range e {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// As finds the first error of the collection matching the given target, so errors.As behaves the same on Go
+// versions without multiple error unwrapping (prior to 1.20)
+func (e Errors) As(target interface{}) bool {
+	for _, err := range e {
+		if errors.As(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// Title retrieves a generic error message
+func (e Errors) Title() string {
+	return validationTitle
+}
+
+// Description retrieves every error description of the collection separated by a semicolon
+func (e Errors) Description() string {
+	var b strings.Builder
+	for i, err := range e {
+		if i > 0 {
+			b.WriteString("; ")
+		}
+		b.WriteString(err.Description())
+	}
+	return b.String()
+}
+
+// Status retrieves the status name of the collection
+func (e Errors) Status() string {
+	return validationStatus
+}
+
+// Group retrieves the collection error group, always Domain
+func (e Errors) Group() string {
+	return domain
+}
+
+// IsGroup checks if the collection belongs to the given error group
+func (e Errors) IsGroup(group string) bool {
+	return group == domain
+}
+
+// IsDomain checks if the collection belongs to Domain error group
+func (e Errors) IsDomain() bool {
+	return true
+}
+
+// IsInfrastructure checks if the collection belongs to Infrastructure error group
+func (e Errors) IsInfrastructure() bool {
+	return false
+}
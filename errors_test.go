@@ -0,0 +1,75 @@
+package ddderr
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestErrors_Append(t *testing.T) {
+	var errs Errors
+	assert.Nil(t, errs.ErrorOrNil())
+
+	genericErr := errors.New("generic error")
+	errs = errs.Append(nil, NewRequired("name"))
+	errs = errs.Append(fmt.Errorf("parse email: %w", NewInvalidFormat("email", "email")))
+	errs = errs.Append(Errors{NewOutOfRange("age", 18, 100)}, genericErr)
+
+	assert.Len(t, errs, 4)
+	assert.NotNil(t, errs.ErrorOrNil())
+	assert.True(t, errs[0].IsRequired())
+	assert.True(t, errs[1].IsInvalidFormat())
+	assert.True(t, errs[2].IsOutOfRange())
+	assert.True(t, errs[3].IsDomain())
+	assert.Equal(t, genericErr, errs[3].Parent())
+	assert.Equal(t, "generic error", errs[3].Description())
+}
+
+func TestErrors_Error(t *testing.T) {
+	errs := Errors{}.Append(NewRequired("name"), NewOutOfRange("age", 18, 100))
+	assert.Equal(t, "The property name is required; The property age is out of range [18,100)", errs.Error())
+	assert.Equal(t, validationTitle, errs.Title())
+	assert.Equal(t, validationStatus, errs.Status())
+	assert.Empty(t, Errors{}.Error())
+}
+
+func TestErrors_Chain(t *testing.T) {
+	var err error = Errors{}.Append(NewRequired("name"), NewNotFound("country"))
+	err = fmt.Errorf("create user: %w", err)
+
+	assert.True(t, errors.Is(err, ErrDomain))
+	assert.True(t, errors.Is(err, ErrRequired))
+	assert.True(t, errors.Is(err, ErrNotFound))
+	assert.False(t, errors.Is(err, ErrInfrastructure))
+	assert.False(t, errors.Is(err, ErrAlreadyExists))
+
+	var errs Errors
+	assert.True(t, errors.As(err, &errs))
+	assert.Len(t, errs, 2)
+
+	assert.True(t, IsDomain(err))
+	assert.False(t, IsInfrastructure(err))
+	assert.False(t, IsNotFound(err))
+	_, ok := As(err)
+	assert.False(t, ok)
+}
+
+func TestErrors_IsAs(t *testing.T) {
+	errs := Errors{}.Append(NewRequired("name"), NewNotFound("country").SetParent(io.EOF))
+
+	// called directly to not depend on multiple error unwrapping support
+	assert.True(t, errs.Is(ErrDomain))
+	assert.True(t, errs.Is(ErrRequired))
+	assert.True(t, errs.Is(io.EOF))
+	assert.False(t, errs.Is(ErrAlreadyExists))
+
+	var customErr Error
+	assert.True(t, errs.As(&customErr))
+	assert.True(t, customErr.IsRequired())
+	var pathErr *os.PathError
+	assert.False(t, errs.As(&pathErr))
+}
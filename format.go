@@ -0,0 +1,113 @@
+package ddderr
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+var _ fmt.Formatter = Error{}
+
+// Format implements fmt.Formatter.
+//
+// Verb %+v prints a multi-line report containing every field of the Error, every parent within the error chain
+// and the stack trace (if captured). Any other verb, flag, width and precision are applied to the error
+// description as if it were a string (e.g. %s, %q, %.5s, %x)
+func (e Error) Format(s fmt.State, verb rune) {
+	if verb == 'v' && s.Flag('+') {
+		writeVerbose(s, e)
+		return
+	}
+	_, _ = fmt.Fprintf(s, newFormatDirective(s, verb), e.Error())
+}
+
+// rebuilds the format directive (e.g. %-10.5s) of the given state and verb
+func newFormatDirective(s fmt.State, verb rune) string {
+	var b strings.Builder
+	b.WriteByte('%')
+	for _, flag := range "+-# 0" {
+		if s.Flag(int(flag)) {
+			b.WriteRune(flag)
+		}
+	}
+	if width, ok := s.Width(); ok {
+		b.WriteString(strconv.Itoa(width))
+	}
+	if precision, ok := s.Precision(); ok {
+		b.WriteByte('.')
+		b.WriteString(strconv.Itoa(precision))
+	}
+	b.WriteRune(verb)
+	return b.String()
+}
+
+// writes the verbose report of the given Error and its parents
+func writeVerbose(w io.Writer, e Error) {
+	writeVerboseError(w, e)
+	for parent := e.Unwrap(); parent != nil; parent = errors.Unwrap(parent) {
+		_, _ = io.WriteString(w, "\ncaused by: ")
+		if customErr, ok := parent.(Error); ok {
+			writeVerboseError(w, customErr)
+			continue
+		}
+		_, _ = io.WriteString(w, parent.Error())
+	}
+}
+
+// writes the fields and stack trace of the given Error, parent is ignored
+func writeVerboseError(w io.Writer, e Error) {
+	_, _ = io.WriteString(w, e.Error())
+	writeVerboseField(w, "group", e.group)
+	writeVerboseField(w, "kind", e.kind)
+	writeVerboseField(w, "property", e.property)
+	writeVerboseField(w, "title", e.title)
+	writeVerboseField(w, "status", e.Status())
+	if e.kind == outOfRange && e.rangeValue != nil {
+		writeVerboseField(w, "range", formatRange(e.lowerBound, e.upperBound))
+		writeVerboseField(w, "value", formatRangeValue(e.rangeValue))
+	} else if e.kind == outOfRange {
+		writeVerboseField(w, "limits", "["+strconv.Itoa(e.limitA)+","+strconv.Itoa(e.limitB)+")")
+	}
+	if e.kind == invalidLength {
+		writeVerboseField(w, "limits", "["+strconv.Itoa(e.limitA)+","+formatLengthMax(e.limitB)+"]")
+		writeVerboseField(w, "length", strconv.Itoa(e.length))
+	}
+	if len(e.formats) > 0 {
+		writeVerboseField(w, "formats", "["+strings.Join(e.formats, ",")+"]")
+	}
+	writeVerboseField(w, "action", e.action)
+	writeVerboseField(w, "challenge", e.challenge)
+	if e.kind == concurrencyConflict {
+		writeVerboseField(w, "versions", "expected "+strconv.FormatInt(e.expectedVersion, 10)+
+			", actual "+strconv.FormatInt(e.actualVersion, 10))
+	}
+	writeVerboseField(w, "rule", e.ruleID)
+	writeVerboseField(w, "reason", e.reason)
+	if e.duration > 0 {
+		writeVerboseField(w, "duration", e.duration.String())
+	}
+	if e.kind == resourceExhausted && e.limitA > 0 {
+		writeVerboseField(w, "limit", strconv.Itoa(e.limitA))
+	}
+	if e.retryAfter > 0 {
+		writeVerboseField(w, "retry after", e.retryAfter.String())
+	}
+
+	trace := e.StackTrace()
+	if len(trace) == 0 {
+		return
+	}
+	_, _ = io.WriteString(w, "\n    stack:")
+	for _, frame := range trace {
+		_, _ = fmt.Fprintf(w, "\n        %s\n            %s:%d", frame.Function, frame.File, frame.Line)
+	}
+}
+
+func writeVerboseField(w io.Writer, name, value string) {
+	if value == "" {
+		return
+	}
+	_, _ = io.WriteString(w, "\n    "+name+": "+value)
+}
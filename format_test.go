@@ -0,0 +1,72 @@
+package ddderr
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestError_Format(t *testing.T) {
+	err := NewNotFound("foo")
+	assert.Equal(t, "The resource foo was not found", fmt.Sprintf("%s", err))
+	assert.Equal(t, "The resource foo was not found", fmt.Sprintf("%v", err))
+	assert.Equal(t, `"The resource foo was not found"`, fmt.Sprintf("%q", err))
+	assert.Equal(t, "The r", fmt.Sprintf("%.5s", err))
+	assert.Equal(t, "The resource foo was not found  ", fmt.Sprintf("%-32v", err))
+	assert.Equal(t, fmt.Sprintf("%x", err.Error()), fmt.Sprintf("%x", err))
+	assert.Equal(t, fmt.Sprintf("% X", err.Error()), fmt.Sprintf("% X", err))
+	assert.Equal(t, "[%!d(string=The resource foo was not found)]", fmt.Sprintf("[%d]", err))
+	assert.Equal(t, "load foo: The resource foo was not found", fmt.Errorf("load foo: %w", err).Error())
+}
+
+func TestError_FormatVerbose(t *testing.T) {
+	rootErr := errors.New("pq: connection refused")
+	err := NewOutOfRange("foo", 8, 16).
+		SetParent(fmt.Errorf("query: %w", NewRemoteCall("localhost:5432").SetParent(rootErr)))
+
+	exp := `The property foo is out of range [8,16)
+    group: Domain
+    kind: OutOfRange
+    property: foo
+    title: Property is out of the specified range
+    status: FooOutOfRange
+    limits: [8,16)
+caused by: query: Failed to call external resource [localhost:5432]
+caused by: Failed to call external resource [localhost:5432]
+    group: Infrastructure
+    kind: FailedRemoteCall
+    property: localhost:5432
+    title: Remote call failed
+    status: FailedRemoteCall
+caused by: pq: connection refused`
+	assert.Equal(t, exp, fmt.Sprintf("%+v", err))
+
+	out := fmt.Sprintf("%+v", NewConcurrencyConflict("order", 3, 4))
+	assert.Contains(t, out, "\n    versions: expected 3, actual 4")
+	out = fmt.Sprintf("%+v", NewFailedPrecondition("order", "order must be paid"))
+	assert.Contains(t, out, "\n    reason: order must be paid")
+	out = fmt.Sprintf("%+v", NewInvariantViolation("ORDER_ALREADY_SHIPPED", "order", "an order cannot be shipped twice"))
+	assert.Contains(t, out, "\n    rule: ORDER_ALREADY_SHIPPED\n    reason: an order cannot be shipped twice")
+
+	out = fmt.Sprintf("%+v", NewOutOfRangeFloat("price", 11, FloatBound{Value: 0.5, Type: Inclusive},
+		FloatBound{}))
+	assert.Contains(t, out, "\n    range: [0.5,+inf)\n    value: 11")
+	assert.NotContains(t, out, "limits:")
+
+	out = fmt.Sprintf("%+v", NewInvalidLength("username", 3, 20, 2))
+	assert.Contains(t, out, "\n    status: UsernameTooShort\n    limits: [3,20]\n    length: 2")
+
+	err = NewInvalidFormat("foo", "jpeg", "gif")
+	assert.Contains(t, fmt.Sprintf("%+v", err), "\n    formats: [jpeg,gif]")
+	assert.NotContains(t, fmt.Sprintf("%+v", err), "stack:")
+}
+
+func TestError_FormatVerboseStackTrace(t *testing.T) {
+	out := fmt.Sprintf("%+v", NewNotFound("foo").WithStackTrace())
+	assert.Contains(t, out, "\n    stack:\n        ")
+	assert.True(t, strings.Contains(out, ".TestError_FormatVerboseStackTrace\n"))
+	assert.Contains(t, out, "format_test.go:")
+}
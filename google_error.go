@@ -0,0 +1,321 @@
+package ddderr
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Google Cloud API canonical error statuses.
+//
+// For more information, go to: https://cloud.google.com/apis/design/errors#handling_errors
+const (
+	GoogleStatusCancelled          = "CANCELLED"
+	GoogleStatusUnknown            = "UNKNOWN"
+	GoogleStatusInvalidArgument    = "INVALID_ARGUMENT"
+	GoogleStatusDeadlineExceeded   = "DEADLINE_EXCEEDED"
+	GoogleStatusNotFound           = "NOT_FOUND"
+	GoogleStatusAlreadyExists      = "ALREADY_EXISTS"
+	GoogleStatusPermissionDenied   = "PERMISSION_DENIED"
+	GoogleStatusUnauthenticated    = "UNAUTHENTICATED"
+	GoogleStatusResourceExhausted  = "RESOURCE_EXHAUSTED"
+	GoogleStatusFailedPrecondition = "FAILED_PRECONDITION"
+	GoogleStatusAborted            = "ABORTED"
+	GoogleStatusUnimplemented      = "UNIMPLEMENTED"
+	GoogleStatusInternal           = "INTERNAL"
+	GoogleStatusUnavailable        = "UNAVAILABLE"
+)
+
+// error detail types
+const (
+	googleErrorInfoType    = "type.googleapis.com/google.rpc.ErrorInfo"
+	googleBadRequestType   = "type.googleapis.com/google.rpc.BadRequest"
+	googleResourceInfoType = "type.googleapis.com/google.rpc.ResourceInfo"
+	googleRetryInfoType    = "type.googleapis.com/google.rpc.RetryInfo"
+)
+
+// googleStatusHttpCodes contains the HTTP status code of each canonical error status
+var googleStatusHttpCodes = map[string]int{
+	GoogleStatusCancelled:          HttpStatusClientClosedRequest,
+	GoogleStatusUnknown:            http.StatusInternalServerError,
+	GoogleStatusInvalidArgument:    http.StatusBadRequest,
+	GoogleStatusDeadlineExceeded:   http.StatusGatewayTimeout,
+	GoogleStatusNotFound:           http.StatusNotFound,
+	GoogleStatusAlreadyExists:      http.StatusConflict,
+	GoogleStatusPermissionDenied:   http.StatusForbidden,
+	GoogleStatusUnauthenticated:    http.StatusUnauthorized,
+	GoogleStatusResourceExhausted:  http.StatusTooManyRequests,
+	GoogleStatusFailedPrecondition: http.StatusBadRequest,
+	GoogleStatusAborted:            http.StatusConflict,
+	GoogleStatusUnimplemented:      http.StatusNotImplemented,
+	GoogleStatusInternal:           http.StatusInternalServerError,
+	GoogleStatusUnavailable:        http.StatusServiceUnavailable,
+}
+
+// GoogleError is a Google Cloud API error model (google.rpc.Status) JSON payload.
+//
+// For more information about the fields, please go to: https://cloud.google.com/apis/design/errors#error_model
+type GoogleError struct {
+	Error GoogleErrorStatus `json:"error"`
+}
+
+// GoogleErrorStatus is the error of a Google Cloud API error model payload.
+//
+// Code is the HTTP status code of the canonical error status while details are typed entries
+// (e.g. GoogleErrorInfo, GoogleBadRequest)
+type GoogleErrorStatus struct {
+	Code    int           `json:"code"`
+	Message string        `json:"message"`
+	Status  string        `json:"status"`
+	Details []interface{} `json:"details,omitempty"`
+}
+
+// GoogleErrorInfo describes the cause of the error (google.rpc.ErrorInfo)
+type GoogleErrorInfo struct {
+	Type     string            `json:"@type"`
+	Reason   string            `json:"reason,omitempty"`
+	Domain   string            `json:"domain,omitempty"`
+	Metadata map[string]string `json:"metadata,omitempty"`
+}
+
+// GoogleBadRequest describes the property failures of a request (google.rpc.BadRequest)
+type GoogleBadRequest struct {
+	Type            string                 `json:"@type"`
+	FieldViolations []GoogleFieldViolation `json:"fieldViolations"`
+}
+
+// GoogleFieldViolation is a property failure of a GoogleBadRequest
+type GoogleFieldViolation struct {
+	Field       string `json:"field"`
+	Description string `json:"description,omitempty"`
+}
+
+// GoogleResourceInfo describes the resource being accessed (google.rpc.ResourceInfo).
+//
+// ResourceName is the Error property (e.g. orders/123) while ResourceType is its collection (e.g. orders), or the
+// property itself if it is not a resource path (e.g. user)
+type GoogleResourceInfo struct {
+	Type         string `json:"@type"`
+	ResourceType string `json:"resourceType,omitempty"`
+	ResourceName string `json:"resourceName,omitempty"`
+	Description  string `json:"description,omitempty"`
+}
+
+// GoogleRetryInfo describes when the caller might retry the request (google.rpc.RetryInfo)
+type GoogleRetryInfo struct {
+	Type       string `json:"@type"`
+	RetryDelay string `json:"retryDelay"`
+}
+
+// NewGoogleError builds a Google Cloud API error model payload from the given error.
+//
+// The outermost DDD error found within the error chain is used to populate the payload. Payload carries an
+// ErrorInfo detail (reason is the Error status name, domain is the given domain), a BadRequest detail for
+// validation failures, a ResourceInfo detail for resource failures and a RetryInfo detail if the Error has a
+// retry delay. If no DDD error was found, a generic UNKNOWN error is returned
+func NewGoogleError(domain string, err error) GoogleError {
+	if err == nil {
+		return GoogleError{}
+	}
+
+	status := GetGoogleStatus(err)
+	payload := GoogleErrorStatus{
+		Code:    googleStatusHttpCodes[status],
+		Message: err.Error(),
+		Status:  status,
+	}
+	target, _ := asGrouped(err)
+	switch customErr := target.(type) {
+	case Error:
+		payload.Message = customErr.Description()
+		payload.Details = append(payload.Details, newGoogleErrorInfo(domain, customErr, customErr.Status()))
+		payload.Details = append(payload.Details, newGoogleErrorDetails(status, customErr)...)
+	case Errors:
+		payload.Message = customErr.Description()
+		payload.Details = append(payload.Details, newGoogleErrorInfo(domain, customErr, customErr.Status()),
+			newGoogleBadRequest(customErr...))
+	}
+	return GoogleError{Error: payload}
+}
+
+func newGoogleErrorInfo(domain string, err groupedError, reason string) GoogleErrorInfo {
+	info := GoogleErrorInfo{
+		Type:     googleErrorInfoType,
+		Reason:   reason,
+		Domain:   domain,
+		Metadata: map[string]string{"group": err.Group()},
+	}
+	if customErr, ok := err.(Error); ok {
+		info.Metadata["kind"] = customErr.Kind()
+		if customErr.Property() != "" {
+			info.Metadata["property"] = customErr.Property()
+		}
+	}
+	return info
+}
+
+// builds the kind-specific details of the given Error
+func newGoogleErrorDetails(status string, err Error) []interface{} {
+	details := make([]interface{}, 0, 2)
+	switch {
+	case status == GoogleStatusInvalidArgument && err.Property() != "":
+		details = append(details, newGoogleBadRequest(err))
+	case err.IsNotFound() || err.IsAlreadyExists() || err.IsPermissionDenied() || err.IsConcurrencyConflict():
+		details = append(details, GoogleResourceInfo{
+			Type:         googleResourceInfoType,
+			ResourceType: getGoogleResourceType(err.Property()),
+			ResourceName: err.Property(),
+			Description:  err.Description(),
+		})
+	}
+	if err.RetryAfter() > 0 {
+		details = append(details, GoogleRetryInfo{
+			Type:       googleRetryInfoType,
+			RetryDelay: formatGoogleDuration(err.RetryAfter()),
+		})
+	}
+	return details
+}
+
+// builds a BadRequest detail containing a field violation for each given Error
+func newGoogleBadRequest(errs ...Error) GoogleBadRequest {
+	violations := make([]GoogleFieldViolation, 0, len(errs))
+	for _, err := range errs {
+		violations = append(violations, GoogleFieldViolation{
+			Field:       err.Property(),
+			Description: err.Description(),
+		})
+	}
+	return GoogleBadRequest{Type: googleBadRequestType, FieldViolations: violations}
+}
+
+// retrieves the resource type of the given resource name (e.g. orders/123 becomes orders)
+func getGoogleResourceType(name string) string {
+	segments := strings.Split(name, "/")
+	if len(segments) < 2 {
+		return name
+	}
+	return segments[len(segments)-2]
+}
+
+// formats the given duration using the protobuf JSON duration format (e.g. 1.5s)
+func formatGoogleDuration(d time.Duration) string {
+	return strconv.FormatFloat(d.Seconds(), 'f', -1, 64) + "s"
+}
+
+// GetGoogleStatus retrieves a Google Cloud API canonical error status from the outermost DDD error found within
+// the given error chain.
+//
+// Kinds with no specific canonical status (e.g. user-defined kinds and groups) are mapped using their HTTP
+// status code.
+//
+// Note: Returns UNKNOWN if no DDD error was found
+func GetGoogleStatus(err error) string {
+	target, ok := asGrouped(err)
+	if !ok {
+		return GoogleStatusUnknown
+	}
+	customErr, ok := target.(Error)
+	if !ok {
+		return GoogleStatusInvalidArgument
+	}
+
+	switch {
+	case customErr.IsUnauthenticated():
+		return GoogleStatusUnauthenticated
+	case customErr.IsPermissionDenied():
+		return GoogleStatusPermissionDenied
+	case customErr.IsAlreadyExists():
+		return GoogleStatusAlreadyExists
+	case customErr.IsConcurrencyConflict():
+		return GoogleStatusAborted
+	case customErr.IsNotFound():
+		return GoogleStatusNotFound
+	case customErr.IsFailedPrecondition() || customErr.IsInvariantViolation():
+		return GoogleStatusFailedPrecondition
+	case customErr.IsInvalidFormat() || customErr.IsRequired() || customErr.IsOutOfRange() ||
+		customErr.IsInvalidLength():
+		return GoogleStatusInvalidArgument
+	case customErr.IsResourceExhausted():
+		return GoogleStatusResourceExhausted
+	case customErr.IsUnavailable() || customErr.IsRemoteCall():
+		return GoogleStatusUnavailable
+	case customErr.IsTimeout():
+		return GoogleStatusDeadlineExceeded
+	case customErr.IsCanceled():
+		return GoogleStatusCancelled
+	default:
+		return getGoogleStatusFromHttpCode(GetHttpStatusCode(customErr))
+	}
+}
+
+// retrieves the canonical error status matching the given HTTP status code
+func getGoogleStatusFromHttpCode(code int) string {
+	switch {
+	case code == http.StatusBadRequest:
+		return GoogleStatusInvalidArgument
+	case code == http.StatusUnauthorized:
+		return GoogleStatusUnauthenticated
+	case code == http.StatusForbidden:
+		return GoogleStatusPermissionDenied
+	case code == http.StatusNotFound:
+		return GoogleStatusNotFound
+	case code == http.StatusConflict:
+		return GoogleStatusAlreadyExists
+	case code == http.StatusTooManyRequests:
+		return GoogleStatusResourceExhausted
+	case code == HttpStatusClientClosedRequest:
+		return GoogleStatusCancelled
+	case code == http.StatusNotImplemented:
+		return GoogleStatusUnimplemented
+	case code == http.StatusServiceUnavailable || code == http.StatusBadGateway:
+		return GoogleStatusUnavailable
+	case code == http.StatusGatewayTimeout:
+		return GoogleStatusDeadlineExceeded
+	case code >= http.StatusBadRequest && code < http.StatusInternalServerError:
+		return GoogleStatusFailedPrecondition
+	default:
+		return GoogleStatusInternal
+	}
+}
+
+// GoogleErrorWriter writes errors into HTTP responses as Google Cloud API error model payloads.
+//
+// The zero value hides the message of errors containing no DDD error
+type GoogleErrorWriter struct {
+	// Domain is set as the ErrorInfo domain (e.g. example.com).
+	Domain string
+	// ExposeInternal writes the message of errors containing no DDD error instead of a generic Internal Server
+	// Error message, such messages might leak infrastructure details (e.g. SQL errors, hostnames).
+	ExposeInternal bool
+}
+
+// WriteGoogleError writes the given error into the HTTP response as a Google Cloud API error model payload using
+// a GoogleErrorWriter with the given domain.
+//
+// Errors containing no DDD error are written as a generic Internal Server Error
+func WriteGoogleError(w http.ResponseWriter, domain string, err error) {
+	GoogleErrorWriter{Domain: domain}.Write(w, err)
+}
+
+// Write writes the given error into the HTTP response as a Google Cloud API error model payload.
+//
+// Error retry delay (if any) is written as the Retry-After header. Nothing is written if err is nil
+func (gw GoogleErrorWriter) Write(w http.ResponseWriter, err error) {
+	if err == nil {
+		return
+	}
+
+	err = hideInternalError(err, gw.ExposeInternal)
+	payload := NewGoogleError(gw.Domain, err)
+	w.Header().Set("Content-Type", "application/json")
+	if customErr, ok := As(err); ok {
+		if retryAfter := getHttpRetryAfter(customErr.RetryAfter()); retryAfter > 0 {
+			w.Header().Set("Retry-After", strconv.Itoa(retryAfter))
+		}
+	}
+	w.WriteHeader(payload.Error.Code)
+	_ = json.NewEncoder(w).Encode(payload)
+}
@@ -0,0 +1,224 @@
+package ddderr
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var newGoogleErrorTestSuite = []struct {
+	InErr   error
+	ExpBody string
+}{
+	{
+		InErr:   nil,
+		ExpBody: `{"error": {"code": 0, "message": "", "status": ""}}`,
+	},
+	{
+		InErr:   errors.New("generic error"),
+		ExpBody: `{"error": {"code": 500, "message": "generic error", "status": "UNKNOWN"}}`,
+	},
+	{
+		InErr: fmt.Errorf("get user: %w", NewNotFound("user")),
+		ExpBody: `{
+			"error": {
+				"code": 404,
+				"message": "The resource user was not found",
+				"status": "NOT_FOUND",
+				"details": [
+					{
+						"@type": "type.googleapis.com/google.rpc.ErrorInfo",
+						"reason": "UserNotFound",
+						"domain": "example.com",
+						"metadata": {"group": "Domain", "kind": "NotFound", "property": "user"}
+					},
+					{
+						"@type": "type.googleapis.com/google.rpc.ResourceInfo",
+						"resourceType": "user",
+						"resourceName": "user",
+						"description": "The resource user was not found"
+					}
+				]
+			}
+		}`,
+	},
+	{
+		InErr: NewPermissionDenied("orders/123", "delete"),
+		ExpBody: `{
+			"error": {
+				"code": 403,
+				"message": "The permission to delete the resource orders/123 was denied",
+				"status": "PERMISSION_DENIED",
+				"details": [
+					{
+						"@type": "type.googleapis.com/google.rpc.ErrorInfo",
+						"reason": "OrdersPermissionDenied",
+						"domain": "example.com",
+						"metadata": {"group": "Domain", "kind": "PermissionDenied", "property": "orders/123"}
+					},
+					{
+						"@type": "type.googleapis.com/google.rpc.ResourceInfo",
+						"resourceType": "orders",
+						"resourceName": "orders/123",
+						"description": "The permission to delete the resource orders/123 was denied"
+					}
+				]
+			}
+		}`,
+	},
+	{
+		InErr: NewRequired("name"),
+		ExpBody: `{
+			"error": {
+				"code": 400,
+				"message": "The property name is required",
+				"status": "INVALID_ARGUMENT",
+				"details": [
+					{
+						"@type": "type.googleapis.com/google.rpc.ErrorInfo",
+						"reason": "NameIsRequired",
+						"domain": "example.com",
+						"metadata": {"group": "Domain", "kind": "Required", "property": "name"}
+					},
+					{
+						"@type": "type.googleapis.com/google.rpc.BadRequest",
+						"fieldViolations": [{"field": "name", "description": "The property name is required"}]
+					}
+				]
+			}
+		}`,
+	},
+	{
+		InErr: Errors{NewRequired("name"), NewInvalidLength("username", 3, 20, 2)},
+		ExpBody: `{
+			"error": {
+				"code": 400,
+				"message": "The property name is required; The property username is too short, minimum length is 3, got 2",
+				"status": "INVALID_ARGUMENT",
+				"details": [
+					{
+						"@type": "type.googleapis.com/google.rpc.ErrorInfo",
+						"reason": "InvalidProperties",
+						"domain": "example.com",
+						"metadata": {"group": "Domain"}
+					},
+					{
+						"@type": "type.googleapis.com/google.rpc.BadRequest",
+						"fieldViolations": [
+							{"field": "name", "description": "The property name is required"},
+							{
+								"field": "username",
+								"description": "The property username is too short, minimum length is 3, got 2"
+							}
+						]
+					}
+				]
+			}
+		}`,
+	},
+	{
+		InErr: NewResourceExhausted("", 100, 1500*time.Millisecond),
+		ExpBody: `{
+			"error": {
+				"code": 429,
+				"message": "quota exhausted, limit is 100, retry after 1.5s",
+				"status": "RESOURCE_EXHAUSTED",
+				"details": [
+					{
+						"@type": "type.googleapis.com/google.rpc.ErrorInfo",
+						"reason": "ResourceExhausted",
+						"domain": "example.com",
+						"metadata": {"group": "Infrastructure", "kind": "ResourceExhausted"}
+					},
+					{"@type": "type.googleapis.com/google.rpc.RetryInfo", "retryDelay": "1.5s"}
+				]
+			}
+		}`,
+	},
+}
+
+func TestNewGoogleError(t *testing.T) {
+	for _, tt := range newGoogleErrorTestSuite {
+		t.Run("", func(t *testing.T) {
+			data, err := json.Marshal(NewGoogleError("example.com", tt.InErr))
+			assert.NoError(t, err)
+			assert.JSONEq(t, tt.ExpBody, string(data))
+		})
+	}
+}
+
+var getGoogleStatusTestSuite = []struct {
+	InErr     error
+	ExpStatus string
+}{
+	{InErr: nil, ExpStatus: GoogleStatusUnknown},
+	{InErr: errors.New("generic error"), ExpStatus: GoogleStatusUnknown},
+	{InErr: NewNotFound("user"), ExpStatus: GoogleStatusNotFound},
+	{InErr: NewAlreadyExists("user"), ExpStatus: GoogleStatusAlreadyExists},
+	{InErr: NewConcurrencyConflict("order", 3, 4), ExpStatus: GoogleStatusAborted},
+	{InErr: NewRequired("name"), ExpStatus: GoogleStatusInvalidArgument},
+	{InErr: NewInvalidFormat("birth_date", "RFC 3339"), ExpStatus: GoogleStatusInvalidArgument},
+	{InErr: NewOutOfRange("age", 18, 100), ExpStatus: GoogleStatusInvalidArgument},
+	{InErr: NewInvalidLength("username", 3, 20, 2), ExpStatus: GoogleStatusInvalidArgument},
+	{InErr: NewDomain("generic title", "generic description"), ExpStatus: GoogleStatusInvalidArgument},
+	{InErr: NewUnauthenticated("token"), ExpStatus: GoogleStatusUnauthenticated},
+	{InErr: NewPermissionDenied("order", "delete"), ExpStatus: GoogleStatusPermissionDenied},
+	{InErr: NewFailedPrecondition("order", ""), ExpStatus: GoogleStatusFailedPrecondition},
+	{InErr: NewInvariantViolation("ORDER_ALREADY_SHIPPED", "order", ""), ExpStatus: GoogleStatusFailedPrecondition},
+	{InErr: NewRemoteCall("localhost:5432"), ExpStatus: GoogleStatusUnavailable},
+	{InErr: NewUnavailable("localhost:5432", 0), ExpStatus: GoogleStatusUnavailable},
+	{InErr: NewResourceExhausted("api_calls", 100, 0), ExpStatus: GoogleStatusResourceExhausted},
+	{InErr: NewTimeout("localhost:5432", time.Second), ExpStatus: GoogleStatusDeadlineExceeded},
+	{InErr: NewCanceled("localhost:5432"), ExpStatus: GoogleStatusCancelled},
+	{InErr: NewInfrastructure("generic title", "generic description"), ExpStatus: GoogleStatusInternal},
+	{InErr: Errors{NewRequired("name")}, ExpStatus: GoogleStatusInvalidArgument},
+}
+
+func TestGetGoogleStatus(t *testing.T) {
+	for _, tt := range getGoogleStatusTestSuite {
+		t.Run(tt.ExpStatus, func(t *testing.T) {
+			assert.Equal(t, tt.ExpStatus, GetGoogleStatus(tt.InErr))
+		})
+	}
+}
+
+func TestWriteGoogleError(t *testing.T) {
+	rec := httptest.NewRecorder()
+	WriteGoogleError(rec, "example.com", nil)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Empty(t, rec.Body.String())
+
+	rec = httptest.NewRecorder()
+	WriteGoogleError(rec, "example.com", NewUnavailable("localhost:5432", 2*time.Second))
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+	assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+	assert.Equal(t, "2", rec.Header().Get("Retry-After"))
+
+	var payload struct {
+		Error struct {
+			Code   int    `json:"code"`
+			Status string `json:"status"`
+		} `json:"error"`
+	}
+	assert.NoError(t, json.NewDecoder(rec.Body).Decode(&payload))
+	assert.Equal(t, http.StatusServiceUnavailable, payload.Error.Code)
+	assert.Equal(t, GoogleStatusUnavailable, payload.Error.Status)
+
+	rec = httptest.NewRecorder()
+	WriteGoogleError(rec, "example.com", errors.New("pq: password authentication failed"))
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	assert.JSONEq(t, `{"error": {"code": 500, "message": "Internal Server Error", "status": "UNKNOWN"}}`,
+		rec.Body.String())
+
+	rec = httptest.NewRecorder()
+	GoogleErrorWriter{Domain: "example.com", ExposeInternal: true}.Write(rec,
+		errors.New("pq: password authentication failed"))
+	assert.JSONEq(t, `{"error": {"code": 500, "message": "pq: password authentication failed", "status": "UNKNOWN"}}`,
+		rec.Body.String())
+}
@@ -0,0 +1,90 @@
+package ddderr
+
+// GraphQLError is a GraphQL specification compliant error object.
+//
+// For more information about the fields, please go to: https://spec.graphql.org/October2021/#sec-Errors
+type GraphQLError struct {
+	Message    string                 `json:"message"`
+	Path       []interface{}          `json:"path,omitempty"`
+	Extensions GraphQLErrorExtensions `json:"extensions"`
+}
+
+// GraphQLErrorExtensions contains the DDD error fields of a GraphQLError.
+//
+// Code is the canonical error status of the error (e.g. NOT_FOUND, INVALID_ARGUMENT) while Status is the Error
+// status name
+type GraphQLErrorExtensions struct {
+	Code     string `json:"code"`
+	Group    string `json:"group,omitempty"`
+	Kind     string `json:"kind,omitempty"`
+	Property string `json:"property,omitempty"`
+	Status   string `json:"status,omitempty"`
+}
+
+var _ error = GraphQLError{}
+
+// Error returns the GraphQL error message
+func (e GraphQLError) Error() string {
+	return e.Message
+}
+
+// GraphQLErrorBuilder builds GraphQL error objects from errors.
+//
+// The zero value hides the message of errors containing no DDD error, NewGraphQLErrors uses the zero value
+type GraphQLErrorBuilder struct {
+	// ExposeInternal sets the message of errors containing no DDD error instead of a generic Internal Server
+	// Error message, such messages might leak infrastructure details (e.g. SQL errors, hostnames).
+	ExposeInternal bool
+}
+
+// NewGraphQLErrors builds the GraphQL error objects of the given error located at the given field path
+// (e.g. []interface{}{"user", "friends", 1, "name"}) using the default GraphQLErrorBuilder.
+//
+// Errors containing no DDD error are built as a generic Internal Server Error
+func NewGraphQLErrors(path []interface{}, err error) []GraphQLError {
+	return GraphQLErrorBuilder{}.Build(path, err)
+}
+
+// Build builds the GraphQL error objects of the given error located at the given field path.
+//
+// The outermost DDD error found within the error chain is used to populate the error objects, an Errors
+// collection is expanded into one error object per property. If no DDD error was found, a generic UNKNOWN error
+// object is returned. Returns nil if err is nil
+func (b GraphQLErrorBuilder) Build(path []interface{}, err error) []GraphQLError {
+	if err == nil {
+		return nil
+	}
+
+	err = hideInternalError(err, b.ExposeInternal)
+	target, _ := asGrouped(err)
+	switch customErr := target.(type) {
+	case Error:
+		return []GraphQLError{newGraphQLError(path, customErr)}
+	case Errors:
+		errs := make([]GraphQLError, 0, len(customErr))
+		for _, e := range customErr {
+			errs = append(errs, newGraphQLError(path, e))
+		}
+		return errs
+	default:
+		return []GraphQLError{{
+			Message:    err.Error(),
+			Path:       path,
+			Extensions: GraphQLErrorExtensions{Code: GoogleStatusUnknown},
+		}}
+	}
+}
+
+func newGraphQLError(path []interface{}, err Error) GraphQLError {
+	return GraphQLError{
+		Message: err.Description(),
+		Path:    path,
+		Extensions: GraphQLErrorExtensions{
+			Code:     GetGoogleStatus(err),
+			Group:    err.Group(),
+			Kind:     err.Kind(),
+			Property: err.Property(),
+			Status:   err.Status(),
+		},
+	}
+}
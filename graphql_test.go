@@ -0,0 +1,107 @@
+package ddderr
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var newGraphQLErrorsTestSuite = []struct {
+	InPath  []interface{}
+	InErr   error
+	ExpBody string
+}{
+	{
+		InPath:  []interface{}{"user"},
+		InErr:   nil,
+		ExpBody: `null`,
+	},
+	{
+		InPath:  nil,
+		InErr:   errors.New("generic error"),
+		ExpBody: `[{"message": "Internal Server Error", "extensions": {"code": "UNKNOWN"}}]`,
+	},
+	{
+		InPath: []interface{}{"user", "friends", 1},
+		InErr:  fmt.Errorf("get user: %w", NewNotFound("user")),
+		ExpBody: `[
+			{
+				"message": "The resource user was not found",
+				"path": ["user", "friends", 1],
+				"extensions": {
+					"code": "NOT_FOUND",
+					"group": "Domain",
+					"kind": "NotFound",
+					"property": "user",
+					"status": "UserNotFound"
+				}
+			}
+		]`,
+	},
+	{
+		InPath: []interface{}{"createUser"},
+		InErr:  fmt.Errorf("create user: %w", Errors{NewRequired("name"), NewInvalidLength("username", 3, 20, 2)}),
+		ExpBody: `[
+			{
+				"message": "The property name is required",
+				"path": ["createUser"],
+				"extensions": {
+					"code": "INVALID_ARGUMENT",
+					"group": "Domain",
+					"kind": "Required",
+					"property": "name",
+					"status": "NameIsRequired"
+				}
+			},
+			{
+				"message": "The property username is too short, minimum length is 3, got 2",
+				"path": ["createUser"],
+				"extensions": {
+					"code": "INVALID_ARGUMENT",
+					"group": "Domain",
+					"kind": "InvalidLength",
+					"property": "username",
+					"status": "UsernameTooShort"
+				}
+			}
+		]`,
+	},
+	{
+		InPath: []interface{}{"orders"},
+		InErr:  NewInfrastructure("generic title", "generic description"),
+		ExpBody: `[
+			{
+				"message": "generic description",
+				"path": ["orders"],
+				"extensions": {"code": "INTERNAL", "group": "Infrastructure", "kind": "UnknownInfrastructure"}
+			}
+		]`,
+	},
+}
+
+func TestNewGraphQLErrors(t *testing.T) {
+	for _, tt := range newGraphQLErrorsTestSuite {
+		t.Run("", func(t *testing.T) {
+			data, err := json.Marshal(NewGraphQLErrors(tt.InPath, tt.InErr))
+			assert.NoError(t, err)
+			assert.JSONEq(t, tt.ExpBody, string(data))
+		})
+	}
+}
+
+func TestGraphQLErrorBuilder_Build(t *testing.T) {
+	errs := GraphQLErrorBuilder{ExposeInternal: true}.Build([]interface{}{"user"},
+		errors.New("pq: password authentication failed"))
+	assert.Len(t, errs, 1)
+	assert.Equal(t, "pq: password authentication failed", errs[0].Message)
+	assert.Equal(t, GoogleStatusUnknown, errs[0].Extensions.Code)
+	assert.Nil(t, GraphQLErrorBuilder{ExposeInternal: true}.Build(nil, nil))
+}
+
+func TestGraphQLError_Error(t *testing.T) {
+	assert.Equal(t, "The resource user was not found",
+		NewGraphQLErrors(nil, NewNotFound("user"))[0].Error())
+}
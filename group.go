@@ -0,0 +1,76 @@
+package ddderr
+
+import (
+	"errors"
+	"net/http"
+	"sync"
+)
+
+// Built-in error groups
+const (
+	GroupDomain         = domain
+	GroupInfrastructure = infrastructure
+)
+
+// GroupSpec describes a user-defined error group (e.g. Application, Presentation).
+//
+// Protocol mappers use the group defaults when the error kind has no specific mapping
+type GroupSpec struct {
+	// Name is the unique group name (e.g. Application)
+	Name string
+	// HTTPStatus is the default HTTP status code of the group, defaults to Internal Server Error (500)
+	HTTPStatus int
+}
+
+// builtinGroups contains every group owned by this package, they cannot be registered
+var builtinGroups = map[string]GroupSpec{
+	domain:         {Name: domain, HTTPStatus: http.StatusBadRequest},
+	infrastructure: {Name: infrastructure, HTTPStatus: http.StatusInternalServerError},
+}
+
+// groupRegistry is a concurrency-safe store of user-defined groups
+type groupRegistry struct {
+	mu     sync.RWMutex
+	groups map[string]GroupSpec
+}
+
+var groups = &groupRegistry{groups: map[string]GroupSpec{}}
+
+// RegisterGroup registers a user-defined error group, registering an existing user-defined group replaces it.
+//
+// Returns an error if name is empty or name belongs to a built-in group
+func RegisterGroup(spec GroupSpec) error {
+	if spec.Name == "" {
+		return errors.New("ddderr: group name is required")
+	}
+	if _, ok := builtinGroups[spec.Name]; ok {
+		return errors.New("ddderr: group " + spec.Name + " is built-in")
+	}
+	if spec.HTTPStatus == 0 {
+		spec.HTTPStatus = http.StatusInternalServerError
+	}
+
+	groups.mu.Lock()
+	groups.groups[spec.Name] = spec
+	groups.mu.Unlock()
+	return nil
+}
+
+// LookupGroup retrieves the specification of a user-defined group
+func LookupGroup(name string) (GroupSpec, bool) {
+	if _, ok := builtinGroups[name]; ok {
+		return GroupSpec{}, false
+	}
+	groups.mu.RLock()
+	spec, ok := groups.groups[name]
+	groups.mu.RUnlock()
+	return spec, ok
+}
+
+// retrieves the specification of either a built-in or a user-defined group
+func lookupGroup(name string) (GroupSpec, bool) {
+	if spec, ok := builtinGroups[name]; ok {
+		return spec, true
+	}
+	return LookupGroup(name)
+}
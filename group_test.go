@@ -0,0 +1,77 @@
+package ddderr
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var registerGroupTestSuite = []struct {
+	In     GroupSpec
+	Exp    GroupSpec
+	ExpErr bool
+}{
+	{
+		In:     GroupSpec{},
+		ExpErr: true,
+	},
+	{
+		In:     GroupSpec{Name: GroupDomain, HTTPStatus: http.StatusUnprocessableEntity},
+		ExpErr: true,
+	},
+	{
+		In:  GroupSpec{Name: "Application"},
+		Exp: GroupSpec{Name: "Application", HTTPStatus: http.StatusInternalServerError},
+	},
+	{
+		In:  GroupSpec{Name: "Presentation", HTTPStatus: http.StatusBadRequest},
+		Exp: GroupSpec{Name: "Presentation", HTTPStatus: http.StatusBadRequest},
+	},
+}
+
+func TestRegisterGroup(t *testing.T) {
+	for _, tt := range registerGroupTestSuite {
+		t.Run(tt.In.Name, func(t *testing.T) {
+			err := RegisterGroup(tt.In)
+			assert.Equal(t, tt.ExpErr, err != nil)
+			spec, ok := LookupGroup(tt.In.Name)
+			assert.Equal(t, !tt.ExpErr, ok)
+			assert.Equal(t, tt.Exp, spec)
+		})
+	}
+}
+
+func TestError_SetGroup(t *testing.T) {
+	assert.NoError(t, RegisterGroup(GroupSpec{Name: "TestPresentation", HTTPStatus: http.StatusBadRequest}))
+
+	err := NewDomain("Malformed body", "request body is not a valid JSON").SetGroup("TestPresentation")
+	assert.Equal(t, "TestPresentation", err.Group())
+	assert.True(t, err.IsGroup("TestPresentation"))
+	assert.False(t, err.IsDomain())
+	assert.False(t, err.IsInfrastructure())
+	assert.False(t, errors.Is(err, ErrDomain))
+	assert.Equal(t, http.StatusBadRequest, GetHttpStatusCode(err))
+	assert.True(t, IsGroup(fmt.Errorf("decode user: %w", err), "TestPresentation"))
+	assert.False(t, IsGroup(fmt.Errorf("decode user: %w", err), GroupDomain))
+
+	err = NewNotFound("user").SetGroup("TestPresentation")
+	assert.Equal(t, http.StatusNotFound, GetHttpStatusCode(err))
+
+	err = NewDomain("generic title", "generic description").SetGroup("TestUnregisteredGroup")
+	assert.Equal(t, http.StatusInternalServerError, GetHttpStatusCode(err))
+
+	assert.NoError(t, RegisterKind(KindSpec{Name: "TestMalformedBody", Group: "TestPresentation"}))
+	err = NewKind("TestMalformedBody", "body")
+	assert.True(t, err.IsGroup("TestPresentation"))
+	assert.Equal(t, http.StatusBadRequest, GetHttpStatusCode(err))
+
+	var errs Errors
+	errs = errs.Append(NewRequired("name"))
+	assert.Equal(t, GroupDomain, errs.Group())
+	assert.True(t, IsGroup(errs, GroupDomain))
+	assert.False(t, IsGroup(errs, "TestPresentation"))
+	assert.False(t, IsGroup(errors.New("foo"), GroupDomain))
+}
@@ -0,0 +1,4 @@
+// Package grpcerr maps DDD errors into gRPC statuses carrying rich error details.
+//
+// grpcerr lives in its own module so the core DDD Error package stays dependency-free.
+package grpcerr
@@ -0,0 +1,23 @@
+module github.com/neutrinocorp/ddderr/v3/grpcerr
+
+go 1.21
+
+require (
+	github.com/neutrinocorp/ddderr/v3 v3.1.0
+	github.com/stretchr/testify v1.7.0
+	google.golang.org/genproto/googleapis/rpc v0.0.0-20240318140521-94a12d6c2237
+	google.golang.org/grpc v1.64.1
+	google.golang.org/protobuf v1.33.0
+)
+
+require (
+	github.com/davecgh/go-spew v1.1.0 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	golang.org/x/net v0.26.0 // indirect
+	golang.org/x/sys v0.21.0 // indirect
+	golang.org/x/text v0.16.0 // indirect
+	gopkg.in/yaml.v3 v3.0.0-20200313102051-9f266ea9e77c // indirect
+)
+
+// local development only, CI also builds this module against the released core version required above
+replace github.com/neutrinocorp/ddderr/v3 => ../
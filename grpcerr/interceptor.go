@@ -0,0 +1,71 @@
+package grpcerr
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+)
+
+// UnaryServerInterceptor returns a server interceptor turning the errors returned by unary handlers into gRPC
+// status errors, domain is set as the ErrorInfo domain
+func UnaryServerInterceptor(domain string) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, _ *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler) (interface{}, error) {
+		res, err := handler(ctx, req)
+		if err != nil {
+			return res, Error(domain, err)
+		}
+		return res, nil
+	}
+}
+
+// StreamServerInterceptor returns a server interceptor turning the errors returned by stream handlers into gRPC
+// status errors, domain is set as the ErrorInfo domain
+func StreamServerInterceptor(domain string) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, _ *grpc.StreamServerInfo,
+		handler grpc.StreamHandler) error {
+		return Error(domain, handler(srv, ss))
+	}
+}
+
+// UnaryClientInterceptor returns a client interceptor turning received gRPC statuses into Error
+func UnaryClientInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn,
+		invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		return fromClientError(invoker(ctx, method, req, reply, cc, opts...))
+	}
+}
+
+// StreamClientInterceptor returns a client interceptor turning received gRPC statuses into Error, both on stream
+// creation and while sending or receiving messages
+func StreamClientInterceptor() grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string,
+		streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		stream, err := streamer(ctx, desc, cc, method, opts...)
+		if err != nil {
+			return nil, fromClientError(err)
+		}
+		return clientStream{ClientStream: stream}, nil
+	}
+}
+
+// clientStream turns the gRPC statuses received by the wrapped stream into Error
+type clientStream struct {
+	grpc.ClientStream
+}
+
+func (s clientStream) SendMsg(m interface{}) error {
+	return fromClientError(s.ClientStream.SendMsg(m))
+}
+
+func (s clientStream) RecvMsg(m interface{}) error {
+	return fromClientError(s.ClientStream.RecvMsg(m))
+}
+
+// builds an Error from the given client error, errors containing no gRPC status (e.g. io.EOF) are returned as is
+func fromClientError(err error) error {
+	if customErr, ok := FromError(err); ok {
+		return customErr
+	}
+	return err
+}
@@ -0,0 +1,130 @@
+package grpcerr
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"testing"
+
+	"github.com/neutrinocorp/ddderr/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// healthServer returns the error registered for each requested service
+type healthServer struct {
+	grpc_health_v1.UnimplementedHealthServer
+	errs map[string]error
+}
+
+func (s healthServer) Check(_ context.Context,
+	req *grpc_health_v1.HealthCheckRequest) (*grpc_health_v1.HealthCheckResponse, error) {
+	if err := s.errs[req.GetService()]; err != nil {
+		return nil, err
+	}
+	return &grpc_health_v1.HealthCheckResponse{Status: grpc_health_v1.HealthCheckResponse_SERVING}, nil
+}
+
+func (s healthServer) Watch(req *grpc_health_v1.HealthCheckRequest,
+	stream grpc_health_v1.Health_WatchServer) error {
+	return s.errs[req.GetService()]
+}
+
+// starts an in-process gRPC server using the given client and server interceptors
+func newHealthClient(t *testing.T, errs map[string]error, withClient bool) grpc_health_v1.HealthClient {
+	lis := bufconn.Listen(1 << 20)
+	srv := grpc.NewServer(
+		grpc.UnaryInterceptor(UnaryServerInterceptor("example.com")),
+		grpc.StreamInterceptor(StreamServerInterceptor("example.com")),
+	)
+	grpc_health_v1.RegisterHealthServer(srv, healthServer{errs: errs})
+	go func() {
+		_ = srv.Serve(lis)
+	}()
+	t.Cleanup(srv.Stop)
+
+	opts := []grpc.DialOption{
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	}
+	if withClient {
+		opts = append(opts,
+			grpc.WithUnaryInterceptor(UnaryClientInterceptor()),
+			grpc.WithStreamInterceptor(StreamClientInterceptor()),
+		)
+	}
+	conn, err := grpc.NewClient("passthrough:///bufnet", opts...)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = conn.Close()
+	})
+	return grpc_health_v1.NewHealthClient(conn)
+}
+
+var healthErrs = map[string]error{
+	"users":  fmt.Errorf("get user: %w", ddderr.NewNotFound("user")),
+	"orders": ddderr.NewInvariantViolation("ORDER_ALREADY_SHIPPED", "order", "an order cannot be shipped twice"),
+	"db":     ddderr.NewRemoteCall("localhost:5432"),
+}
+
+func TestServerInterceptors(t *testing.T) {
+	client := newHealthClient(t, healthErrs, false)
+	ctx := context.Background()
+
+	_, err := client.Check(ctx, &grpc_health_v1.HealthCheckRequest{Service: "users"})
+	st, ok := status.FromError(err)
+	assert.True(t, ok)
+	assert.Equal(t, codes.NotFound, st.Code())
+	assert.Equal(t, "The resource user was not found", st.Message())
+	assert.NotEmpty(t, st.Details())
+
+	res, err := client.Check(ctx, &grpc_health_v1.HealthCheckRequest{Service: "payments"})
+	assert.NoError(t, err)
+	assert.Equal(t, grpc_health_v1.HealthCheckResponse_SERVING, res.GetStatus())
+
+	stream, err := client.Watch(ctx, &grpc_health_v1.HealthCheckRequest{Service: "db"})
+	require.NoError(t, err)
+	_, err = stream.Recv()
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+}
+
+func TestClientInterceptors(t *testing.T) {
+	client := newHealthClient(t, healthErrs, true)
+	ctx := context.Background()
+
+	_, err := client.Check(ctx, &grpc_health_v1.HealthCheckRequest{Service: "users"})
+	assert.True(t, ddderr.IsNotFound(err))
+	customErr, ok := ddderr.As(err)
+	require.True(t, ok)
+	assert.Equal(t, "user", customErr.Property())
+	assert.Equal(t, "UserNotFound", customErr.Status())
+	assert.Equal(t, "The resource user was not found", customErr.Description())
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	_, err = client.Check(ctx, &grpc_health_v1.HealthCheckRequest{Service: "orders"})
+	assert.True(t, ddderr.IsInvariantViolation(err))
+	customErr, _ = ddderr.As(err)
+	assert.Equal(t, "ORDER_ALREADY_SHIPPED", customErr.RuleID())
+
+	_, err = client.Check(ctx, &grpc_health_v1.HealthCheckRequest{Service: "payments"})
+	assert.NoError(t, err)
+
+	stream, err := client.Watch(ctx, &grpc_health_v1.HealthCheckRequest{Service: "db"})
+	require.NoError(t, err)
+	_, err = stream.Recv()
+	assert.True(t, ddderr.IsRemoteCall(err))
+	assert.True(t, ddderr.IsInfrastructure(err))
+
+	stream, err = client.Watch(ctx, &grpc_health_v1.HealthCheckRequest{Service: "payments"})
+	require.NoError(t, err)
+	_, err = stream.Recv()
+	assert.False(t, ddderr.IsDomain(err) || ddderr.IsInfrastructure(err))
+}
@@ -0,0 +1,171 @@
+package grpcerr
+
+import (
+	"time"
+
+	"github.com/neutrinocorp/ddderr/v3"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// FromError builds an Error from the gRPC status found within the given error chain.
+//
+// Returns false if err contains no gRPC status or if the status code is OK
+func FromError(err error) (ddderr.Error, bool) {
+	if err == nil {
+		return ddderr.Error{}, false
+	}
+	st, ok := status.FromError(err)
+	if !ok {
+		return ddderr.Error{}, false
+	}
+	return FromStatus(st)
+}
+
+// FromStatus builds an Error from the given gRPC status and its error details.
+//
+// The Error kind, group and property are taken from the ErrorInfo detail (if any), otherwise the kind is
+// inferred from the status code. The ErrorInfo reason is set as the Error status name and the status error is
+// attached as parent. If the BadRequest detail contains more than one field violation (e.g. a status built from an
+// Errors collection), then an Errors collection with one Error per violated field is attached as parent instead
+// and the status error is attached as parent of each field Error.
+//
+// Returns false if st is nil or if the status code is OK
+func FromStatus(st *status.Status) (ddderr.Error, bool) {
+	if st == nil || st.Code() == codes.OK {
+		return ddderr.Error{}, false
+	}
+
+	var (
+		info       *errdetails.ErrorInfo
+		violations []*errdetails.BadRequest_FieldViolation
+		property   string
+		retryAfter time.Duration
+	)
+	for _, detail := range st.Details() {
+		switch d := detail.(type) {
+		case *errdetails.ErrorInfo:
+			info = d
+		case *errdetails.ResourceInfo:
+			property = d.GetResourceName()
+		case *errdetails.BadRequest:
+			violations = d.GetFieldViolations()
+			if len(violations) == 1 {
+				property = violations[0].GetField()
+			}
+		case *errdetails.RetryInfo:
+			retryAfter = d.GetRetryDelay().AsDuration()
+		}
+	}
+
+	metadata := info.GetMetadata()
+	if p := metadata["property"]; p != "" {
+		property = p
+	}
+	customErr, ok := newErrorFromKind(metadata["kind"], property, info.GetReason(), retryAfter)
+	if !ok {
+		customErr = newErrorFromCode(st.Code(), property, retryAfter)
+	}
+	if group := metadata["group"]; group != "" && group != customErr.Group() {
+		customErr = customErr.SetGroup(group)
+	}
+	if st.Message() != "" {
+		customErr = customErr.SetDescription(st.Message())
+	}
+	if reason := info.GetReason(); reason != "" {
+		customErr = customErr.SetStatus(reason)
+	}
+	if len(violations) > 1 {
+		return customErr.SetParent(newErrorsFromViolations(st, violations)), true
+	}
+	return customErr.SetParent(st.Err()), true
+}
+
+// builds an Errors collection containing an Error for each given field violation, the status error is attached as
+// parent of each Error
+func newErrorsFromViolations(st *status.Status, violations []*errdetails.BadRequest_FieldViolation) ddderr.Errors {
+	errs := make(ddderr.Errors, 0, len(violations))
+	for _, violation := range violations {
+		errs = append(errs, ddderr.NewDomain(codes.InvalidArgument.String(), violation.GetDescription()).
+			SetProperty(violation.GetField()).
+			SetDescription(violation.GetDescription()).
+			SetStatus(codes.InvalidArgument.String()).
+			SetParent(st.Err()))
+	}
+	return errs
+}
+
+// builds an Error from the given kind name, returns false if kind is unknown
+func newErrorFromKind(kind, property, reason string, retryAfter time.Duration) (ddderr.Error, bool) {
+	switch kind {
+	case "":
+		return ddderr.Error{}, false
+	case "NotFound":
+		return ddderr.NewNotFound(property), true
+	case "AlreadyExists":
+		return ddderr.NewAlreadyExists(property), true
+	case "OutOfRange":
+		return ddderr.NewOutOfRange(property, 0, 0), true
+	case "InvalidFormat":
+		return ddderr.NewInvalidFormat(property), true
+	case "Required":
+		return ddderr.NewRequired(property), true
+	case "InvalidLength":
+		return ddderr.NewInvalidLength(property, 0, 0, 0), true
+	case "FailedRemoteCall":
+		return ddderr.NewRemoteCall(property), true
+	case "Unauthenticated":
+		return ddderr.NewUnauthenticated(property), true
+	case "PermissionDenied":
+		return ddderr.NewPermissionDenied(property, ""), true
+	case "ConcurrencyConflict":
+		return ddderr.NewConcurrencyConflict(property, 0, 0), true
+	case "FailedPrecondition":
+		return ddderr.NewFailedPrecondition(property, ""), true
+	case "InvariantViolation":
+		return ddderr.NewInvariantViolation(reason, property, ""), true
+	case "DeadlineExceeded":
+		return ddderr.NewTimeout(property, 0), true
+	case "Canceled":
+		return ddderr.NewCanceled(property), true
+	case "ResourceExhausted":
+		return ddderr.NewResourceExhausted(property, 0, retryAfter), true
+	case "Unavailable":
+		return ddderr.NewUnavailable(property, retryAfter), true
+	}
+	if _, ok := ddderr.LookupKind(kind); ok {
+		return ddderr.NewKind(kind, property), true
+	}
+	return ddderr.Error{}, false
+}
+
+// builds an Error from a gRPC status code
+func newErrorFromCode(code codes.Code, property string, retryAfter time.Duration) ddderr.Error {
+	switch code {
+	case codes.NotFound:
+		return ddderr.NewNotFound(property)
+	case codes.AlreadyExists:
+		return ddderr.NewAlreadyExists(property)
+	case codes.Aborted:
+		return ddderr.NewConcurrencyConflict(property, 0, 0)
+	case codes.Unauthenticated:
+		return ddderr.NewUnauthenticated(property)
+	case codes.PermissionDenied:
+		return ddderr.NewPermissionDenied(property, "")
+	case codes.FailedPrecondition:
+		return ddderr.NewFailedPrecondition(property, "")
+	case codes.ResourceExhausted:
+		return ddderr.NewResourceExhausted(property, 0, retryAfter)
+	case codes.Unavailable:
+		return ddderr.NewUnavailable(property, retryAfter)
+	case codes.DeadlineExceeded:
+		return ddderr.NewTimeout(property, 0)
+	case codes.Canceled:
+		return ddderr.NewCanceled(property)
+	case codes.InvalidArgument, codes.OutOfRange:
+		return ddderr.NewDomain(code.String(), code.String())
+	default:
+		return ddderr.NewInfrastructure(code.String(), code.String())
+	}
+}
@@ -0,0 +1,151 @@
+package grpcerr
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/neutrinocorp/ddderr/v3"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var fromStatusTestSuite = []struct {
+	InErr       error
+	ExpKind     string
+	ExpGroup    string
+	ExpProperty string
+	ExpDesc     string
+	ExpStatus   string
+}{
+	{
+		InErr:       ddderr.NewNotFound("user"),
+		ExpKind:     "NotFound",
+		ExpGroup:    ddderr.GroupDomain,
+		ExpProperty: "user",
+		ExpDesc:     "The resource user was not found",
+		ExpStatus:   "UserNotFound",
+	},
+	{
+		InErr:       ddderr.NewAlreadyExists("user"),
+		ExpKind:     "AlreadyExists",
+		ExpGroup:    ddderr.GroupDomain,
+		ExpProperty: "user",
+		ExpDesc:     "The resource user already exists",
+		ExpStatus:   "UserAlreadyExists",
+	},
+	{
+		InErr:       ddderr.NewRequired("name"),
+		ExpKind:     "Required",
+		ExpGroup:    ddderr.GroupDomain,
+		ExpProperty: "name",
+		ExpDesc:     "The property name is required",
+		ExpStatus:   "NameIsRequired",
+	},
+	{
+		InErr:       ddderr.NewInvalidLength("username", 3, 20, 2),
+		ExpKind:     "InvalidLength",
+		ExpGroup:    ddderr.GroupDomain,
+		ExpProperty: "username",
+		ExpDesc:     "The property username is too short, minimum length is 3, got 2",
+		ExpStatus:   "UsernameTooShort",
+	},
+	{
+		InErr:       ddderr.NewInvariantViolation("ORDER_ALREADY_SHIPPED", "order", "an order cannot be shipped twice"),
+		ExpKind:     "InvariantViolation",
+		ExpGroup:    ddderr.GroupDomain,
+		ExpProperty: "order",
+		ExpDesc:     "The aggregate order violated the rule ORDER_ALREADY_SHIPPED: an order cannot be shipped twice",
+		ExpStatus:   "ORDER_ALREADY_SHIPPED",
+	},
+	{
+		InErr:       ddderr.NewRemoteCall("localhost:5432"),
+		ExpKind:     "FailedRemoteCall",
+		ExpGroup:    ddderr.GroupInfrastructure,
+		ExpProperty: "localhost:5432",
+		ExpDesc:     "Failed to call external resource [localhost:5432]",
+		ExpStatus:   "FailedRemoteCall",
+	},
+	{
+		InErr:     ddderr.NewInfrastructure("generic title", "generic description"),
+		ExpKind:   "UnknownInfrastructure",
+		ExpGroup:  ddderr.GroupInfrastructure,
+		ExpDesc:   "generic description",
+		ExpStatus: "",
+	},
+	{
+		InErr:     status.Error(codes.NotFound, "user not found"),
+		ExpKind:   "NotFound",
+		ExpGroup:  ddderr.GroupDomain,
+		ExpDesc:   "user not found",
+		ExpStatus: "NotFound",
+	},
+	{
+		InErr:     status.Error(codes.Internal, "internal error"),
+		ExpKind:   "UnknownInfrastructure",
+		ExpGroup:  ddderr.GroupInfrastructure,
+		ExpDesc:   "internal error",
+		ExpStatus: "",
+	},
+}
+
+func TestFromStatus(t *testing.T) {
+	for _, tt := range fromStatusTestSuite {
+		t.Run(tt.ExpKind, func(t *testing.T) {
+			st := NewStatus("example.com", tt.InErr)
+			out, ok := FromStatus(st)
+			assert.True(t, ok)
+			assert.Equal(t, tt.ExpKind, out.Kind())
+			assert.Equal(t, tt.ExpGroup, out.Group())
+			assert.Equal(t, tt.ExpProperty, out.Property())
+			assert.Equal(t, tt.ExpDesc, out.Description())
+			assert.Equal(t, tt.ExpStatus, out.Status())
+			assert.Equal(t, st.Code(), GetCode(out))
+			assert.Equal(t, st.Code(), status.Code(out))
+		})
+	}
+}
+
+func TestFromStatus_RetryInfo(t *testing.T) {
+	st := NewStatus("example.com", ddderr.NewResourceExhausted("api_calls", 100, 2*time.Second))
+	out, ok := FromStatus(st)
+	assert.True(t, ok)
+	assert.True(t, out.IsResourceExhausted())
+	assert.Equal(t, 2*time.Second, out.RetryAfter())
+}
+
+func TestFromStatus_FieldViolations(t *testing.T) {
+	var errs ddderr.Errors
+	errs = errs.Append(ddderr.NewRequired("name"), ddderr.NewInvalidLength("username", 3, 20, 2))
+	out, ok := FromStatus(NewStatus("example.com", errs))
+	assert.True(t, ok)
+	assert.True(t, out.IsDomain())
+	assert.Equal(t, "InvalidProperties", out.Status())
+	assert.Equal(t, codes.InvalidArgument, GetCode(out))
+
+	var outErrs ddderr.Errors
+	assert.True(t, errors.As(out, &outErrs))
+	assert.Len(t, outErrs, 2)
+	assert.Equal(t, "name", outErrs[0].Property())
+	assert.Equal(t, "The property name is required", outErrs[0].Description())
+	assert.Equal(t, "username", outErrs[1].Property())
+	assert.Equal(t, "The property username is too short, minimum length is 3, got 2", outErrs[1].Description())
+	assert.Equal(t, codes.InvalidArgument, status.Code(outErrs[1]))
+}
+
+func TestFromError(t *testing.T) {
+	_, ok := FromError(nil)
+	assert.False(t, ok)
+	_, ok = FromError(errors.New("generic error"))
+	assert.False(t, ok)
+	_, ok = FromStatus(nil)
+	assert.False(t, ok)
+	_, ok = FromStatus(status.New(codes.OK, ""))
+	assert.False(t, ok)
+
+	out, ok := FromError(Error("example.com", ddderr.NewPermissionDenied("order", "delete")))
+	assert.True(t, ok)
+	assert.True(t, out.IsPermissionDenied())
+	assert.Equal(t, "order", out.Property())
+}
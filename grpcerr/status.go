@@ -0,0 +1,145 @@
+package grpcerr
+
+import (
+	"errors"
+	"time"
+
+	"github.com/neutrinocorp/ddderr/v3"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// googleStatusCodes contains the gRPC status code of each Google Cloud API canonical error status
+var googleStatusCodes = map[string]codes.Code{
+	ddderr.GoogleStatusCancelled:          codes.Canceled,
+	ddderr.GoogleStatusUnknown:            codes.Unknown,
+	ddderr.GoogleStatusInvalidArgument:    codes.InvalidArgument,
+	ddderr.GoogleStatusDeadlineExceeded:   codes.DeadlineExceeded,
+	ddderr.GoogleStatusNotFound:           codes.NotFound,
+	ddderr.GoogleStatusAlreadyExists:      codes.AlreadyExists,
+	ddderr.GoogleStatusPermissionDenied:   codes.PermissionDenied,
+	ddderr.GoogleStatusUnauthenticated:    codes.Unauthenticated,
+	ddderr.GoogleStatusResourceExhausted:  codes.ResourceExhausted,
+	ddderr.GoogleStatusFailedPrecondition: codes.FailedPrecondition,
+	ddderr.GoogleStatusAborted:            codes.Aborted,
+	ddderr.GoogleStatusUnimplemented:      codes.Unimplemented,
+	ddderr.GoogleStatusInternal:           codes.Internal,
+	ddderr.GoogleStatusUnavailable:        codes.Unavailable,
+}
+
+// dddError is implemented by every error type of the ddderr package (i.e. ddderr.Error and ddderr.Errors)
+type dddError interface {
+	error
+	Group() string
+	Status() string
+	Description() string
+}
+
+var (
+	_ dddError = ddderr.Error{}
+	_ dddError = ddderr.Errors{}
+)
+
+// reports whether the given error chain contains a DDD error
+func isDDD(err error) bool {
+	var target dddError
+	return err != nil && errors.As(err, &target)
+}
+
+// NewStatus builds a gRPC status from the outermost DDD error found within the given error chain.
+//
+// The status carries the same details as ddderr.NewGoogleError: an ErrorInfo detail (reason is the Error status
+// name, domain is the given domain), a BadRequest detail for validation failures, a ResourceInfo detail for
+// resource failures and a RetryInfo detail if the Error has a retry delay. If err already contains a gRPC status,
+// such status is returned as is.
+//
+// Note: Returns an Unknown status if no DDD error was found, returns nil if err is nil
+func NewStatus(domain string, err error) *status.Status {
+	if err == nil {
+		return nil
+	}
+	if !isDDD(err) {
+		if st, isStatus := status.FromError(err); isStatus {
+			return st
+		}
+		return status.New(codes.Unknown, err.Error())
+	}
+
+	payload := ddderr.NewGoogleError(domain, err).Error
+	st := status.New(googleStatusCodes[payload.Status], payload.Message)
+	details := make([]protoadapt.MessageV1, 0, len(payload.Details))
+	for _, detail := range payload.Details {
+		if msg := newDetail(detail); msg != nil {
+			details = append(details, msg)
+		}
+	}
+	if withDetails, detailsErr := st.WithDetails(details...); detailsErr == nil {
+		return withDetails
+	}
+	return st
+}
+
+// Error builds a gRPC status error from the outermost DDD error found within the given error chain, useful
+// to return DDD errors from gRPC handlers
+//
+// Note: Returns nil if err is nil
+func Error(domain string, err error) error {
+	if err == nil {
+		return nil
+	}
+	return NewStatus(domain, err).Err()
+}
+
+// converts the given Google Cloud API error detail into its protobuf message, returns nil if the detail is
+// unknown
+func newDetail(detail interface{}) protoadapt.MessageV1 {
+	switch d := detail.(type) {
+	case ddderr.GoogleErrorInfo:
+		return &errdetails.ErrorInfo{
+			Reason:   d.Reason,
+			Domain:   d.Domain,
+			Metadata: d.Metadata,
+		}
+	case ddderr.GoogleBadRequest:
+		violations := make([]*errdetails.BadRequest_FieldViolation, 0, len(d.FieldViolations))
+		for _, violation := range d.FieldViolations {
+			violations = append(violations, &errdetails.BadRequest_FieldViolation{
+				Field:       violation.Field,
+				Description: violation.Description,
+			})
+		}
+		return &errdetails.BadRequest{FieldViolations: violations}
+	case ddderr.GoogleResourceInfo:
+		return &errdetails.ResourceInfo{
+			ResourceType: d.ResourceType,
+			ResourceName: d.ResourceName,
+			Description:  d.Description,
+		}
+	case ddderr.GoogleRetryInfo:
+		delay, err := time.ParseDuration(d.RetryDelay)
+		if err != nil {
+			return nil
+		}
+		return &errdetails.RetryInfo{RetryDelay: durationpb.New(delay)}
+	default:
+		return nil
+	}
+}
+
+// GetCode retrieves a gRPC status code from the outermost DDD error found within the given error chain.
+//
+// The code is derived from the Google Cloud API canonical error status returned by ddderr.GetGoogleStatus.
+//
+// Note: Returns OK if err is nil and Unknown if neither a DDD error nor a gRPC status was found
+func GetCode(err error) codes.Code {
+	if err == nil {
+		return codes.OK
+	}
+	if !isDDD(err) {
+		return status.Code(err)
+	}
+	return googleStatusCodes[ddderr.GetGoogleStatus(err)]
+}
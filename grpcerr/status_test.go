@@ -0,0 +1,190 @@
+package grpcerr
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/neutrinocorp/ddderr/v3"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var getCodeTestSuite = []struct {
+	InErr   error
+	ExpCode codes.Code
+}{
+	{
+		InErr:   nil,
+		ExpCode: codes.OK,
+	},
+	{
+		InErr:   errors.New("generic error"),
+		ExpCode: codes.Unknown,
+	},
+	{
+		InErr:   status.Error(codes.Unimplemented, "not implemented"),
+		ExpCode: codes.Unimplemented,
+	},
+	{
+		InErr:   ddderr.NewNotFound("user"),
+		ExpCode: codes.NotFound,
+	},
+	{
+		InErr:   fmt.Errorf("get user: %w", ddderr.NewNotFound("user")),
+		ExpCode: codes.NotFound,
+	},
+	{
+		InErr:   ddderr.NewAlreadyExists("user"),
+		ExpCode: codes.AlreadyExists,
+	},
+	{
+		InErr:   ddderr.NewConcurrencyConflict("order", 3, 4),
+		ExpCode: codes.Aborted,
+	},
+	{
+		InErr:   ddderr.NewRequired("name"),
+		ExpCode: codes.InvalidArgument,
+	},
+	{
+		InErr:   ddderr.NewInvalidFormat("birth_date", "RFC 3339"),
+		ExpCode: codes.InvalidArgument,
+	},
+	{
+		InErr:   ddderr.NewOutOfRange("age", 18, 100),
+		ExpCode: codes.InvalidArgument,
+	},
+	{
+		InErr:   ddderr.NewInvalidLength("username", 3, 20, 2),
+		ExpCode: codes.InvalidArgument,
+	},
+	{
+		InErr:   ddderr.Errors{ddderr.NewRequired("name")},
+		ExpCode: codes.InvalidArgument,
+	},
+	{
+		InErr:   ddderr.NewDomain("generic title", "generic description"),
+		ExpCode: codes.InvalidArgument,
+	},
+	{
+		InErr:   ddderr.NewUnauthenticated("token"),
+		ExpCode: codes.Unauthenticated,
+	},
+	{
+		InErr:   ddderr.NewPermissionDenied("order", "delete"),
+		ExpCode: codes.PermissionDenied,
+	},
+	{
+		InErr:   ddderr.NewFailedPrecondition("order", "order must be paid"),
+		ExpCode: codes.FailedPrecondition,
+	},
+	{
+		InErr:   ddderr.NewInvariantViolation("ORDER_ALREADY_SHIPPED", "order", ""),
+		ExpCode: codes.FailedPrecondition,
+	},
+	{
+		InErr:   ddderr.NewRemoteCall("localhost:5432"),
+		ExpCode: codes.Unavailable,
+	},
+	{
+		InErr:   ddderr.NewUnavailable("localhost:5432", time.Second),
+		ExpCode: codes.Unavailable,
+	},
+	{
+		InErr:   ddderr.NewResourceExhausted("api_calls", 100, time.Second),
+		ExpCode: codes.ResourceExhausted,
+	},
+	{
+		InErr:   ddderr.NewTimeout("localhost:5432", time.Second),
+		ExpCode: codes.DeadlineExceeded,
+	},
+	{
+		InErr:   ddderr.NewCanceled("localhost:5432"),
+		ExpCode: codes.Canceled,
+	},
+	{
+		InErr:   ddderr.NewInfrastructure("generic title", "generic description"),
+		ExpCode: codes.Internal,
+	},
+}
+
+func TestGetCode(t *testing.T) {
+	for _, tt := range getCodeTestSuite {
+		t.Run("", func(t *testing.T) {
+			assert.Equal(t, tt.ExpCode, GetCode(tt.InErr))
+		})
+	}
+}
+
+func TestGetCode_UserDefinedKind(t *testing.T) {
+	assert.NoError(t, ddderr.RegisterKind(ddderr.KindSpec{
+		Name:       "InsufficientFunds",
+		HTTPStatus: http.StatusPaymentRequired,
+	}))
+	assert.NoError(t, ddderr.RegisterKind(ddderr.KindSpec{Name: "SkuNotFound", Parent: "NotFound"}))
+
+	assert.Equal(t, codes.FailedPrecondition, GetCode(ddderr.NewKind("InsufficientFunds", "savings")))
+	assert.Equal(t, codes.NotFound, GetCode(ddderr.NewKind("SkuNotFound", "sku")))
+}
+
+func TestNewStatus(t *testing.T) {
+	assert.Nil(t, NewStatus("example.com", nil))
+	assert.Nil(t, Error("example.com", nil))
+
+	st := NewStatus("example.com", errors.New("generic error"))
+	assert.Equal(t, codes.Unknown, st.Code())
+	assert.Equal(t, "generic error", st.Message())
+	assert.Empty(t, st.Details())
+
+	inSt := status.New(codes.Unimplemented, "not implemented")
+	assert.Equal(t, inSt, NewStatus("example.com", inSt.Err()))
+
+	st = NewStatus("example.com", ddderr.NewRequired("name"))
+	assert.Equal(t, codes.InvalidArgument, st.Code())
+	assert.Equal(t, "The property name is required", st.Message())
+	assert.Len(t, st.Details(), 2)
+	info := st.Details()[0].(*errdetails.ErrorInfo)
+	assert.Equal(t, "NameIsRequired", info.GetReason())
+	assert.Equal(t, "example.com", info.GetDomain())
+	assert.Equal(t, map[string]string{"group": "Domain", "kind": "Required", "property": "name"},
+		info.GetMetadata())
+	badRequest := st.Details()[1].(*errdetails.BadRequest)
+	assert.Len(t, badRequest.GetFieldViolations(), 1)
+	assert.Equal(t, "name", badRequest.GetFieldViolations()[0].GetField())
+	assert.Equal(t, "The property name is required", badRequest.GetFieldViolations()[0].GetDescription())
+
+	st = NewStatus("example.com", fmt.Errorf("get user: %w", ddderr.NewNotFound("user")))
+	assert.Equal(t, codes.NotFound, st.Code())
+	assert.Len(t, st.Details(), 2)
+	resource := st.Details()[1].(*errdetails.ResourceInfo)
+	assert.Equal(t, "user", resource.GetResourceType())
+	assert.Equal(t, "user", resource.GetResourceName())
+	assert.Equal(t, "The resource user was not found", resource.GetDescription())
+
+	resource = NewStatus("", ddderr.NewPermissionDenied("orders/123", "delete")).Details()[1].(*errdetails.ResourceInfo)
+	assert.Equal(t, "orders", resource.GetResourceType())
+	assert.Equal(t, "orders/123", resource.GetResourceName())
+
+	st = NewStatus("", ddderr.NewResourceExhausted("api_calls", 100, 1500*time.Millisecond))
+	assert.Equal(t, codes.ResourceExhausted, st.Code())
+	assert.Len(t, st.Details(), 2)
+	assert.Equal(t, "ApiCallsResourceExhausted", st.Details()[0].(*errdetails.ErrorInfo).GetReason())
+	assert.Equal(t, 1500*time.Millisecond, st.Details()[1].(*errdetails.RetryInfo).GetRetryDelay().AsDuration())
+
+	var errs ddderr.Errors
+	errs = errs.Append(ddderr.NewRequired("name"), ddderr.NewInvalidLength("username", 3, 20, 2))
+	err := Error("example.com", errs)
+	st, ok := status.FromError(err)
+	assert.True(t, ok)
+	assert.Equal(t, codes.InvalidArgument, st.Code())
+	assert.Len(t, st.Details(), 2)
+	assert.Equal(t, "InvalidProperties", st.Details()[0].(*errdetails.ErrorInfo).GetReason())
+	violations := st.Details()[1].(*errdetails.BadRequest).GetFieldViolations()
+	assert.Len(t, violations, 2)
+	assert.Equal(t, "name", violations[0].GetField())
+	assert.Equal(t, "username", violations[1].GetField())
+}
@@ -0,0 +1,219 @@
+package ddderr
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maximum number of bytes read from an HTTP response body by FromHttpResponse (1 MiB)
+const maxHttpResponseBodySize = 1 << 20
+
+var _ error = HttpError{}
+
+// Error returns the HTTP problem object detail, title is returned if detail is empty
+func (e HttpError) Error() string {
+	if e.Detail != "" {
+		return e.Detail
+	}
+	return e.Title
+}
+
+// ParseHttpError decodes an HTTP problem object (either HttpProblem or HttpError) and builds an Error from it.
+//
+// The Error kind is inferred from the problem status code and status name, and the decoded problem object
+// (HttpProblem or HttpError) is attached as parent. If the problem object contains an invalid-params extension
+// member, an Errors collection with one Error per property is attached as parent instead and the decoded
+// problem object is attached as parent of each property Error
+func ParseHttpError(data []byte) (Error, error) {
+	return parseHttpError(data, 0, 0)
+}
+
+// FromHttpResponse decodes the body of an HTTP response containing a problem object (either HttpProblem or
+// HttpError) and builds an Error from it.
+//
+// If the body is not a valid problem object, the Error is built using the response status code and the raw body
+// is attached as parent. The WWW-Authenticate header (if any) is set as the Error challenge and the Retry-After
+// header is used if the problem object has no retry delay. Up to 1 MiB of the response body is read, the body
+// is not closed
+func FromHttpResponse(res *http.Response) (Error, error) {
+	if res == nil {
+		return Error{}, errors.New("ddderr: nil http response")
+	} else if res.StatusCode < http.StatusBadRequest {
+		return Error{}, errors.New("ddderr: http response is not an error, got status " + res.Status)
+	}
+
+	var data []byte
+	if res.Body != nil {
+		var err error
+		if data, err = ioutil.ReadAll(io.LimitReader(res.Body, maxHttpResponseBodySize)); err != nil {
+			return Error{}, err
+		}
+	}
+
+	retryAfter := parseHttpRetryAfter(res.Header.Get("Retry-After"))
+	customErr, err := parseHttpError(data, res.StatusCode, retryAfter)
+	if err != nil {
+		customErr = newErrorFromHttpStatus(res.StatusCode, "", retryAfter).
+			SetDescription(http.StatusText(res.StatusCode))
+		if len(data) > 0 {
+			customErr = customErr.SetParent(errors.New(string(data)))
+		}
+	}
+	if challenge := res.Header.Get("WWW-Authenticate"); challenge != "" {
+		customErr = customErr.SetChallenge(challenge)
+	}
+	return customErr, nil
+}
+
+// decodes either an HttpProblem or an HttpError and builds an Error from it, defaultCode and defaultRetryAfter
+// are used if the problem object has no status code or retry delay
+func parseHttpError(data []byte, defaultCode int, defaultRetryAfter time.Duration) (Error, error) {
+	var probe struct {
+		Status     json.RawMessage `json:"status"`
+		StatusCode int             `json:"status_code"`
+	}
+	if err := json.Unmarshal(data, &probe); err != nil {
+		return Error{}, err
+	}
+
+	// legacy (v3) shape contains the status name as status and the status code as status_code
+	if probe.StatusCode != 0 || (len(probe.Status) > 0 && probe.Status[0] == '"') {
+		var httpErr HttpError
+		if err := json.Unmarshal(data, &httpErr); err != nil {
+			return Error{}, err
+		}
+		if httpErr.StatusCode == 0 {
+			httpErr.StatusCode = defaultCode
+		}
+		retryAfter := defaultRetryAfter
+		if httpErr.RetryAfter > 0 {
+			retryAfter = time.Duration(httpErr.RetryAfter) * time.Second
+		}
+		customErr := newErrorFromHttpProblem(httpErr.StatusCode, "", httpErr.Status, httpErr.Title,
+			httpErr.Detail, retryAfter)
+		return setHttpInvalidParamsParent(customErr, httpErr.InvalidParams, httpErr), nil
+	}
+
+	var problem HttpProblem
+	if err := json.Unmarshal(data, &problem); err != nil {
+		return Error{}, err
+	}
+	if problem.Status == 0 {
+		problem.Status = defaultCode
+	}
+	statusName, _ := problem.Extensions[httpProblemCodeMember].(string)
+	retryAfter := defaultRetryAfter
+	if seconds, ok := problem.Extensions[httpProblemRetryAfterMember].(float64); ok && seconds > 0 {
+		retryAfter = time.Duration(seconds) * time.Second
+	}
+	var members struct {
+		InvalidParams []HttpInvalidParam `json:"invalid-params"`
+	}
+	_ = json.Unmarshal(data, &members)
+	customErr := newErrorFromHttpProblem(problem.Status, "", statusName, problem.Title, problem.Detail, retryAfter)
+	return setHttpInvalidParamsParent(customErr, members.InvalidParams, problem), nil
+}
+
+// attaches the given problem object as parent of the Error, if invalid params were given, then an Errors
+// collection is attached instead and the problem object is attached as parent of each property Error
+func setHttpInvalidParamsParent(err Error, params []HttpInvalidParam, problem error) Error {
+	if len(params) == 0 {
+		return err.SetParent(problem)
+	}
+	errs := make(Errors, 0, len(params))
+	for _, param := range params {
+		errs = append(errs, newErrorFromHttpProblem(http.StatusBadRequest, param.Name, param.Status, param.Title,
+			param.Detail, 0).SetParent(problem))
+	}
+	return err.SetParent(errs)
+}
+
+// parses the Retry-After HTTP header, either delay seconds or an HTTP date are accepted.
+//
+// For more information, go to: https://datatracker.ietf.org/doc/html/rfc9110#section-10.2.3
+func parseHttpRetryAfter(header string) time.Duration {
+	if header == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(header); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	if date, err := http.ParseTime(header); err == nil {
+		if d := time.Until(date); d > 0 {
+			return d
+		}
+	}
+	return 0
+}
+
+// builds an Error from the given HTTP problem object fields
+func newErrorFromHttpProblem(code int, property, statusName, title, detail string, retryAfter time.Duration) Error {
+	err := newErrorFromHttpStatus(code, statusName, retryAfter)
+	if property != "" {
+		err = err.SetProperty(property)
+	}
+	if title != "" {
+		err = err.SetTitle(title)
+	}
+	if detail != "" {
+		err = err.SetDescription(detail)
+	}
+	if statusName != "" && statusName != http.StatusText(code) {
+		err = err.SetStatus(statusName)
+	}
+	return err
+}
+
+// builds an Error from an HTTP status code, status name is used to infer a user-defined kind or the specific
+// kind of a Bad Request or a Conflict
+func newErrorFromHttpStatus(code int, statusName string, retryAfter time.Duration) Error {
+	if spec, ok := lookupKindByHttpStatus(code, statusName); ok {
+		return NewKind(spec.Name, "")
+	}
+
+	switch {
+	case code == http.StatusTooManyRequests:
+		return NewResourceExhausted("", 0, retryAfter)
+	case code == http.StatusServiceUnavailable:
+		return NewUnavailable("", retryAfter)
+	case code == http.StatusUnauthorized:
+		return NewUnauthenticated("")
+	case code == http.StatusForbidden:
+		return NewPermissionDenied("", "")
+	case code == http.StatusNotFound:
+		return NewNotFound("")
+	case code == http.StatusPreconditionFailed:
+		return NewFailedPrecondition("", "")
+	case code == http.StatusUnprocessableEntity && statusName != http.StatusText(code):
+		return NewInvariantViolation(statusName, "", "")
+	case code == http.StatusConflict && strings.HasSuffix(statusName, concurrencyConflict):
+		return NewConcurrencyConflict("", 0, 0)
+	case code == http.StatusConflict:
+		return NewAlreadyExists("")
+	case code == http.StatusBadGateway:
+		return NewRemoteCall("")
+	case code == http.StatusGatewayTimeout:
+		return NewTimeout("", 0)
+	case code == HttpStatusClientClosedRequest:
+		return NewCanceled("")
+	case code == http.StatusBadRequest && strings.HasSuffix(statusName, invalidFormat):
+		return NewInvalidFormat("")
+	case code == http.StatusBadRequest && strings.HasSuffix(statusName, required):
+		return NewRequired("")
+	case code == http.StatusBadRequest && (strings.HasSuffix(statusName, tooShort) ||
+		strings.HasSuffix(statusName, tooLong) || strings.HasSuffix(statusName, invalidLength)):
+		return NewInvalidLength("", 0, 0, 0)
+	case code == http.StatusBadRequest && strings.HasSuffix(statusName, outOfRange):
+		return NewOutOfRange("", 0, 0)
+	case code >= http.StatusBadRequest && code < http.StatusInternalServerError:
+		return NewDomain(http.StatusText(code), http.StatusText(code))
+	default:
+		return NewInfrastructure(http.StatusText(code), http.StatusText(code))
+	}
+}
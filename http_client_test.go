@@ -0,0 +1,337 @@
+package ddderr
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var parseHttpErrorTestSuite = []struct {
+	InErr      error
+	ExpKind    string
+	ExpDomain  bool
+	ExpTitle   string
+	ExpDesc    string
+	ExpStatus  string
+	ExpHttpErr bool
+}{
+	{
+		InErr:     NewNotFound("user"),
+		ExpKind:   notFound,
+		ExpDomain: true,
+		ExpTitle:  "Resource not found",
+		ExpDesc:   "The resource user was not found",
+		ExpStatus: "UserNotFound",
+	},
+	{
+		InErr:     fmt.Errorf("create user: %w", NewAlreadyExists("user")),
+		ExpKind:   alreadyExists,
+		ExpDomain: true,
+		ExpTitle:  "Resource already exists",
+		ExpDesc:   "The resource user already exists",
+		ExpStatus: "UserAlreadyExists",
+	},
+	{
+		InErr:     NewInvalidFormat("email", "email"),
+		ExpKind:   invalidFormat,
+		ExpDomain: true,
+		ExpTitle:  "Property is not a valid format",
+		ExpDesc:   "The property email has an invalid format, expected [email]",
+		ExpStatus: "EmailInvalidFormat",
+	},
+	{
+		InErr:     NewRequired("name"),
+		ExpKind:   required,
+		ExpDomain: true,
+		ExpTitle:  "Missing property",
+		ExpDesc:   "The property name is required",
+		ExpStatus: "NameIsRequired",
+	},
+	{
+		InErr:     NewOutOfRange("age", 18, 100),
+		ExpKind:   outOfRange,
+		ExpDomain: true,
+		ExpTitle:  "Property is out of the specified range",
+		ExpDesc:   "The property age is out of range [18,100)",
+		ExpStatus: "AgeOutOfRange",
+	},
+	{
+		InErr:     NewRequired("bar").SetProperty("foo"),
+		ExpKind:   required,
+		ExpDomain: true,
+		ExpTitle:  "Missing property",
+		ExpDesc:   "The property foo is required",
+		ExpStatus: "FooRequired",
+	},
+	{
+		InErr:     NewInvalidLength("username", 3, 20, 21),
+		ExpKind:   invalidLength,
+		ExpDomain: true,
+		ExpTitle:  "Property has an invalid length",
+		ExpDesc:   "The property username is too long, maximum length is 20, got 21",
+		ExpStatus: "UsernameTooLong",
+	},
+	{
+		InErr:     NewDomain("generic title", "specific description"),
+		ExpKind:   unknownDomain,
+		ExpDomain: true,
+		ExpTitle:  "generic title",
+		ExpDesc:   "specific description",
+		ExpStatus: "",
+	},
+	{
+		InErr:     NewUnauthenticated("access_token"),
+		ExpKind:   unauthenticated,
+		ExpDomain: true,
+		ExpTitle:  "Unauthenticated",
+		ExpDesc:   "The credential access_token is missing, invalid or expired",
+		ExpStatus: "AccessTokenUnauthenticated",
+	},
+	{
+		InErr:     NewPermissionDenied("user", "delete"),
+		ExpKind:   permissionDenied,
+		ExpDomain: true,
+		ExpTitle:  "Permission denied",
+		ExpDesc:   "The permission to delete the resource user was denied",
+		ExpStatus: "UserPermissionDenied",
+	},
+	{
+		InErr:     NewConcurrencyConflict("order", 3, 4),
+		ExpKind:   concurrencyConflict,
+		ExpDomain: true,
+		ExpTitle:  "Concurrency conflict",
+		ExpDesc:   "The aggregate order has a concurrency conflict, expected version 3 but got 4",
+		ExpStatus: "OrderConcurrencyConflict",
+	},
+	{
+		InErr:     NewFailedPrecondition("order", "order must be paid"),
+		ExpKind:   failedPrecondition,
+		ExpDomain: true,
+		ExpTitle:  "Precondition failed",
+		ExpDesc:   "The precondition for order failed: order must be paid",
+		ExpStatus: "OrderFailedPrecondition",
+	},
+	{
+		InErr:     NewInvariantViolation("ORDER_ALREADY_SHIPPED", "order", "an order cannot be shipped twice"),
+		ExpKind:   invariantViolation,
+		ExpDomain: true,
+		ExpTitle:  "Business rule violated",
+		ExpDesc:   "The aggregate order violated the rule ORDER_ALREADY_SHIPPED: an order cannot be shipped twice",
+		ExpStatus: "ORDER_ALREADY_SHIPPED",
+	},
+	{
+		InErr:     NewRemoteCall("localhost:5432"),
+		ExpKind:   remoteCall,
+		ExpDomain: false,
+		ExpTitle:  "Remote call failed",
+		ExpDesc:   "Failed to call external resource [localhost:5432]",
+		ExpStatus: "FailedRemoteCall",
+	},
+	{
+		InErr:     NewTimeout("localhost:5432", time.Second),
+		ExpKind:   timeout,
+		ExpDomain: false,
+		ExpTitle:  "Deadline exceeded",
+		ExpDesc:   "Timed out after 1s while calling external resource [localhost:5432]",
+		ExpStatus: "DeadlineExceeded",
+	},
+	{
+		InErr:     NewCanceled("localhost:5432"),
+		ExpKind:   canceled,
+		ExpDomain: false,
+		ExpTitle:  "Operation canceled",
+		ExpDesc:   "Operation canceled while calling external resource [localhost:5432]",
+		ExpStatus: "Canceled",
+	},
+	{
+		InErr:     NewUnavailable("localhost:5432", time.Second*30),
+		ExpKind:   unavailable,
+		ExpDomain: false,
+		ExpTitle:  "Service unavailable",
+		ExpDesc:   "External resource [localhost:5432] is unavailable, retry after 30s",
+		ExpStatus: "Unavailable",
+	},
+	{
+		InErr:     errors.New("generic error"),
+		ExpKind:   unknownInfrastructure,
+		ExpDomain: false,
+		ExpTitle:  "generic error",
+		ExpDesc:   "generic error",
+		ExpStatus: "",
+	},
+}
+
+func TestParseHttpError(t *testing.T) {
+	for _, tt := range parseHttpErrorTestSuite {
+		t.Run("", func(t *testing.T) {
+			httpErr := NewHttpError("", "/users", tt.InErr)
+			data, err := json.Marshal(httpErr)
+			assert.NoError(t, err)
+
+			out, err := ParseHttpError(data)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.ExpKind, out.Kind())
+			assert.Equal(t, tt.ExpDomain, out.IsDomain())
+			assert.Equal(t, !tt.ExpDomain, out.IsInfrastructure())
+			assert.Equal(t, tt.ExpTitle, out.Title())
+			assert.Equal(t, tt.ExpDesc, out.Description())
+			assert.Equal(t, tt.ExpStatus, out.Status())
+			assert.Equal(t, httpErr, out.Parent())
+			assert.Equal(t, GetHttpStatusCode(tt.InErr), GetHttpStatusCode(out))
+
+			data, err = json.Marshal(NewHttpProblem("", "/users", tt.InErr))
+			assert.NoError(t, err)
+			out, err = ParseHttpError(data)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.ExpKind, out.Kind())
+			assert.Equal(t, tt.ExpTitle, out.Title())
+			assert.Equal(t, tt.ExpDesc, out.Description())
+			assert.Equal(t, tt.ExpStatus, out.Status())
+			assert.IsType(t, HttpProblem{}, out.Parent())
+			assert.Equal(t, GetHttpStatusCode(tt.InErr), GetHttpStatusCode(out))
+			if customErr, ok := As(tt.InErr); ok {
+				assert.Equal(t, customErr.RetryAfter(), out.RetryAfter())
+			}
+		})
+	}
+
+	_, err := ParseHttpError([]byte("not a json"))
+	assert.Error(t, err)
+}
+
+func TestParseHttpError_InvalidParams(t *testing.T) {
+	var errs Errors
+	errs = errs.Append(NewRequired("name"), NewInvalidLength("username", 3, 20, 2))
+
+	legacy, err := json.Marshal(NewHttpError("", "/users", errs))
+	assert.NoError(t, err)
+	problem, err := json.Marshal(NewHttpProblem("", "/users", errs))
+	assert.NoError(t, err)
+	for _, data := range [][]byte{legacy, problem} {
+		out, err := ParseHttpError(data)
+		assert.NoError(t, err)
+		assert.True(t, out.IsDomain())
+		assert.Equal(t, http.StatusBadRequest, GetHttpStatusCode(out))
+
+		var outErrs Errors
+		assert.True(t, errors.As(out, &outErrs))
+		assert.Len(t, outErrs, 2)
+		assert.True(t, outErrs[0].IsRequired())
+		assert.Equal(t, "name", outErrs[0].Property())
+		assert.Equal(t, "NameIsRequired", outErrs[0].Status())
+		assert.Equal(t, "The property name is required", outErrs[0].Description())
+		assert.True(t, outErrs[1].IsInvalidLength())
+		assert.Equal(t, "username", outErrs[1].Property())
+		assert.Equal(t, "UsernameTooShort", outErrs[1].Status())
+		assert.NotNil(t, outErrs[1].Parent())
+	}
+}
+
+func TestFromHttpResponse(t *testing.T) {
+	_, err := FromHttpResponse(nil)
+	assert.Error(t, err)
+
+	rec := httptest.NewRecorder()
+	rec.WriteHeader(http.StatusOK)
+	_, err = FromHttpResponse(rec.Result())
+	assert.Error(t, err)
+
+	rec = httptest.NewRecorder()
+	rec.WriteHeader(http.StatusNotFound)
+	_ = json.NewEncoder(rec).Encode(HttpProblem{
+		Title:      "Resource not found",
+		Detail:     "The resource user was not found",
+		Extensions: map[string]interface{}{"code": "UserNotFound"},
+	})
+	out, err := FromHttpResponse(rec.Result())
+	assert.NoError(t, err)
+	assert.True(t, out.IsNotFound())
+	assert.Equal(t, "The resource user was not found", out.Description())
+	assert.Equal(t, "UserNotFound", out.Status())
+	assert.True(t, IsNotFound(fmt.Errorf("get user: %w", out)))
+
+	rec = httptest.NewRecorder()
+	rec.Header().Set("WWW-Authenticate", `Bearer realm="example"`)
+	rec.WriteHeader(http.StatusUnauthorized)
+	out, err = FromHttpResponse(rec.Result())
+	assert.NoError(t, err)
+	assert.True(t, out.IsUnauthenticated())
+	assert.Equal(t, `Bearer realm="example"`, out.Challenge())
+
+	rec = httptest.NewRecorder()
+	rec.Header().Set("Retry-After", "120")
+	rec.WriteHeader(http.StatusTooManyRequests)
+	out, err = FromHttpResponse(rec.Result())
+	assert.NoError(t, err)
+	assert.True(t, out.IsResourceExhausted())
+	assert.Equal(t, time.Minute*2, out.RetryAfter())
+
+	rec = httptest.NewRecorder()
+	rec.Header().Set("Retry-After", "120")
+	WriteHttpError(rec, nil, NewUnavailable("localhost:5432", time.Second*30))
+	out, err = FromHttpResponse(rec.Result())
+	assert.NoError(t, err)
+	assert.True(t, out.IsUnavailable())
+	assert.Equal(t, time.Second*30, out.RetryAfter())
+
+	// legacy (v3) shape without status code
+	rec = httptest.NewRecorder()
+	rec.WriteHeader(http.StatusConflict)
+	_ = json.NewEncoder(rec).Encode(HttpError{
+		Title:  "Resource already exists",
+		Detail: "The resource user already exists",
+		Status: "UserAlreadyExists",
+	})
+	out, err = FromHttpResponse(rec.Result())
+	assert.NoError(t, err)
+	assert.True(t, out.IsAlreadyExists())
+	assert.Equal(t, "UserAlreadyExists", out.Status())
+	assert.IsType(t, HttpError{}, out.Parent())
+
+	rec = httptest.NewRecorder()
+	rec.WriteHeader(http.StatusBadGateway)
+	_, _ = rec.WriteString("upstream connect error")
+	out, err = FromHttpResponse(rec.Result())
+	assert.NoError(t, err)
+	assert.True(t, out.IsRemoteCall())
+	assert.Equal(t, "Bad Gateway", out.Description())
+	assert.EqualError(t, out.Parent(), "upstream connect error")
+
+	rec = httptest.NewRecorder()
+	rec.WriteHeader(http.StatusBadGateway)
+	_, _ = rec.WriteString(strings.Repeat("a", maxHttpResponseBodySize+1))
+	out, err = FromHttpResponse(rec.Result())
+	assert.NoError(t, err)
+	assert.Len(t, out.Parent().Error(), maxHttpResponseBodySize)
+
+	rec = httptest.NewRecorder()
+	rec.WriteHeader(http.StatusServiceUnavailable)
+	out, err = FromHttpResponse(rec.Result())
+	assert.NoError(t, err)
+	assert.True(t, out.IsInfrastructure())
+	assert.Equal(t, "Service Unavailable", out.Description())
+	assert.Nil(t, out.Parent())
+}
+
+func TestHttpError_Error(t *testing.T) {
+	assert.Equal(t, "specific description", HttpError{Title: "generic title", Detail: "specific description"}.Error())
+	assert.Equal(t, "generic title", HttpError{Title: "generic title"}.Error())
+}
+
+func TestParseHttpRetryAfter(t *testing.T) {
+	assert.Zero(t, parseHttpRetryAfter(""))
+	assert.Zero(t, parseHttpRetryAfter("foo"))
+	assert.Zero(t, parseHttpRetryAfter("-1"))
+	assert.Equal(t, time.Second*120, parseHttpRetryAfter("120"))
+	assert.Zero(t, parseHttpRetryAfter("Wed, 21 Oct 2015 07:28:00 GMT"))
+
+	d := parseHttpRetryAfter(time.Now().Add(time.Hour).UTC().Format(http.TimeFormat))
+	assert.True(t, d > time.Minute*59 && d <= time.Hour)
+}
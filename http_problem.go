@@ -0,0 +1,167 @@
+package ddderr
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+const (
+	// HttpProblemDefaultType is the HTTP problem details type used when no type was specified.
+	//
+	// For more information, go to: https://datatracker.ietf.org/doc/html/rfc9457#section-4.2.1
+	HttpProblemDefaultType = "about:blank"
+
+	// extension members
+	httpProblemCodeMember          = "code"
+	httpProblemInvalidParamsMember = "invalid-params"
+	httpProblemRetryAfterMember    = "retry_after"
+)
+
+// HttpProblem is an RFC 7807 / RFC 9457 compliant HTTP problem details object.
+//
+// Unlike HttpError, Status is the HTTP status code and extension members are flattened into the problem object
+// when marshalled. The Error status name is set as the "code" extension member and the Error retry delay
+// (in seconds) as the "retry_after" extension member.
+//
+// For more information about the fields, please go to: https://datatracker.ietf.org/doc/html/rfc9457
+type HttpProblem struct {
+	Type       string
+	Title      string
+	Status     int
+	Detail     string
+	Instance   string
+	Extensions map[string]interface{}
+}
+
+var (
+	_ error            = HttpProblem{}
+	_ json.Marshaler   = HttpProblem{}
+	_ json.Unmarshaler = &HttpProblem{}
+)
+
+// NewHttpProblem builds an HttpProblem from the given error.
+//
+// The outermost DDD error found within the error chain is used to populate the HttpProblem, if none was found,
+// a generic Internal Server Error is returned. If errType is empty, then HttpProblemDefaultType is used
+func NewHttpProblem(errType, instance string, err error) HttpProblem {
+	if err == nil {
+		return HttpProblem{}
+	}
+
+	httpErr := NewHttpError(errType, instance, err)
+	if errType == "" {
+		errType = HttpProblemDefaultType
+	}
+	problem := HttpProblem{
+		Type:     errType,
+		Title:    httpErr.Title,
+		Status:   httpErr.StatusCode,
+		Detail:   httpErr.Detail,
+		Instance: httpErr.Instance,
+	}
+	if httpErr.Status != "" && httpErr.Status != http.StatusText(httpErr.StatusCode) {
+		problem = problem.SetExtension(httpProblemCodeMember, httpErr.Status)
+	}
+	if len(httpErr.InvalidParams) > 0 {
+		problem = problem.SetExtension(httpProblemInvalidParamsMember, httpErr.InvalidParams)
+	}
+	if httpErr.RetryAfter > 0 {
+		problem = problem.SetExtension(httpProblemRetryAfterMember, httpErr.RetryAfter)
+	}
+	return problem
+}
+
+// Error returns the HTTP problem detail, title is returned if detail is empty
+func (p HttpProblem) Error() string {
+	if p.Detail != "" {
+		return p.Detail
+	}
+	return p.Title
+}
+
+// Extension retrieves an extension member of the HTTP problem
+func (p HttpProblem) Extension(name string) (interface{}, bool) {
+	v, ok := p.Extensions[name]
+	return v, ok
+}
+
+// SetExtension sets an extension member of the HTTP problem.
+//
+// Standard members (type, title, status, detail and instance) cannot be overridden by extension members
+func (p HttpProblem) SetExtension(name string, value interface{}) HttpProblem {
+	extensions := make(map[string]interface{}, len(p.Extensions)+1)
+	for k, v := range p.Extensions {
+		extensions[k] = v
+	}
+	extensions[name] = value
+	p.Extensions = extensions
+	return p
+}
+
+// MarshalJSON encodes the HTTP problem, extension members are flattened into the problem object
+func (p HttpProblem) MarshalJSON() ([]byte, error) {
+	members := make(map[string]interface{}, len(p.Extensions)+5)
+	for k, v := range p.Extensions {
+		members[k] = v
+	}
+
+	members["type"] = p.Type
+	if p.Type == "" {
+		members["type"] = HttpProblemDefaultType
+	}
+	setHttpProblemMember(members, "title", p.Title)
+	if p.Status != 0 {
+		members["status"] = p.Status
+	} else {
+		delete(members, "status")
+	}
+	setHttpProblemMember(members, "detail", p.Detail)
+	setHttpProblemMember(members, "instance", p.Instance)
+	return json.Marshal(members)
+}
+
+func setHttpProblemMember(members map[string]interface{}, name, value string) {
+	if value == "" {
+		delete(members, name)
+		return
+	}
+	members[name] = value
+}
+
+// UnmarshalJSON decodes an HTTP problem, unknown members are decoded as extension members
+func (p *HttpProblem) UnmarshalJSON(data []byte) error {
+	var members map[string]json.RawMessage
+	if err := json.Unmarshal(data, &members); err != nil {
+		return err
+	}
+
+	problem := HttpProblem{}
+	for name, raw := range members {
+		var err error
+		switch name {
+		case "type":
+			err = json.Unmarshal(raw, &problem.Type)
+		case "title":
+			err = json.Unmarshal(raw, &problem.Title)
+		case "status":
+			err = json.Unmarshal(raw, &problem.Status)
+		case "detail":
+			err = json.Unmarshal(raw, &problem.Detail)
+		case "instance":
+			err = json.Unmarshal(raw, &problem.Instance)
+		default:
+			var v interface{}
+			if err = json.Unmarshal(raw, &v); err == nil {
+				if problem.Extensions == nil {
+					problem.Extensions = make(map[string]interface{})
+				}
+				problem.Extensions[name] = v
+			}
+		}
+		if err != nil {
+			return err
+		}
+	}
+	*p = problem
+	return nil
+}
@@ -0,0 +1,149 @@
+package ddderr
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var newHttpProblemTestSuite = []struct {
+	InErrType  string
+	InInstance string
+	InErr      error
+	Exp        HttpProblem
+}{
+	{
+		InErrType:  "https://neutrinocorp.org/iam/probs/generic-error",
+		InInstance: "/users/12345",
+		InErr:      nil,
+		Exp:        HttpProblem{},
+	},
+	{
+		InErrType:  "",
+		InInstance: "/users/12345",
+		InErr:      errors.New("generic error"),
+		Exp: HttpProblem{
+			Type:     HttpProblemDefaultType,
+			Title:    "generic error",
+			Status:   http.StatusInternalServerError,
+			Detail:   "generic error",
+			Instance: "/users/12345",
+		},
+	},
+	{
+		InErrType:  "https://neutrinocorp.org/iam/probs/not-found",
+		InInstance: "/users/12345",
+		InErr:      fmt.Errorf("load user: %w", NewNotFound("user")),
+		Exp: HttpProblem{
+			Type:     "https://neutrinocorp.org/iam/probs/not-found",
+			Title:    "Resource not found",
+			Status:   http.StatusNotFound,
+			Detail:   "The resource user was not found",
+			Instance: "/users/12345",
+			Extensions: map[string]interface{}{
+				"code": "UserNotFound",
+			},
+		},
+	},
+	{
+		InErrType:  "",
+		InInstance: "",
+		InErr:      NewDomain("generic title", "specific description"),
+		Exp: HttpProblem{
+			Type:   HttpProblemDefaultType,
+			Title:  "generic title",
+			Status: http.StatusBadRequest,
+			Detail: "specific description",
+		},
+	},
+	{
+		InErrType:  "",
+		InInstance: "/users",
+		InErr:      Errors{}.Append(NewRequired("name")),
+		Exp: HttpProblem{
+			Type:     HttpProblemDefaultType,
+			Title:    "One or more properties are invalid",
+			Status:   http.StatusBadRequest,
+			Detail:   "The property name is required",
+			Instance: "/users",
+			Extensions: map[string]interface{}{
+				"code": "InvalidProperties",
+				"invalid-params": []HttpInvalidParam{
+					{
+						Name:   "name",
+						Title:  "Missing property",
+						Detail: "The property name is required",
+						Status: "NameIsRequired",
+					},
+				},
+			},
+		},
+	},
+}
+
+func TestNewHttpProblem(t *testing.T) {
+	for _, tt := range newHttpProblemTestSuite {
+		t.Run("", func(t *testing.T) {
+			problem := NewHttpProblem(tt.InErrType, tt.InInstance, tt.InErr)
+			assert.Equal(t, tt.Exp, problem)
+		})
+	}
+}
+
+func TestHttpProblem_MarshalJSON(t *testing.T) {
+	problem := HttpProblem{
+		Title:  "Resource not found",
+		Status: http.StatusNotFound,
+	}.SetExtension("balance", 30).
+		SetExtension("accounts", []string{"/account/12345"}).
+		SetExtension("status", "overridden").
+		SetExtension("detail", "overridden")
+	data, err := json.Marshal(problem)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{
+		"type": "about:blank",
+		"title": "Resource not found",
+		"status": 404,
+		"balance": 30,
+		"accounts": ["/account/12345"]
+	}`, string(data))
+
+	v, ok := problem.Extension("balance")
+	assert.True(t, ok)
+	assert.Equal(t, 30, v)
+	_, ok = problem.Extension("foo")
+	assert.False(t, ok)
+}
+
+func TestHttpProblem_UnmarshalJSON(t *testing.T) {
+	var problem HttpProblem
+	err := json.Unmarshal([]byte(`{
+		"type": "https://example.com/probs/out-of-credit",
+		"title": "You do not have enough credit.",
+		"status": 403,
+		"detail": "Your current balance is 30, but that costs 50.",
+		"instance": "/account/12345/msgs/abc",
+		"balance": 30,
+		"accounts": ["/account/12345", "/account/67890"]
+	}`), &problem)
+	assert.NoError(t, err)
+	assert.Equal(t, HttpProblem{
+		Type:     "https://example.com/probs/out-of-credit",
+		Title:    "You do not have enough credit.",
+		Status:   http.StatusForbidden,
+		Detail:   "Your current balance is 30, but that costs 50.",
+		Instance: "/account/12345/msgs/abc",
+		Extensions: map[string]interface{}{
+			"balance":  float64(30),
+			"accounts": []interface{}{"/account/12345", "/account/67890"},
+		},
+	}, problem)
+	assert.Equal(t, "Your current balance is 30, but that costs 50.", problem.Error())
+
+	assert.Error(t, json.Unmarshal([]byte(`{"status": "Not Found"}`), &problem))
+	assert.Error(t, json.Unmarshal([]byte(`[]`), &problem))
+}
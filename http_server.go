@@ -0,0 +1,101 @@
+package ddderr
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+)
+
+// HttpProblemContentType is the media type of an HTTP problem object.
+//
+// For more information, go to: https://datatracker.ietf.org/doc/html/rfc7807#section-6.1
+const HttpProblemContentType = "application/problem+json"
+
+// HttpProblemWriter writes errors into HTTP responses as HTTP problem objects.
+//
+// The zero value writes HttpProblem objects and hides the message of errors containing no DDD error,
+// WriteHttpError uses the zero value
+type HttpProblemWriter struct {
+	// Legacy writes the legacy (v3) HttpError shape instead of HttpProblem, useful to keep existing clients working.
+	Legacy bool
+	// ExposeInternal writes the message of errors containing no DDD error instead of a generic Internal Server
+	// Error message, such messages might leak infrastructure details (e.g. SQL errors, hostnames).
+	ExposeInternal bool
+}
+
+// WriteHttpError writes the given error into the HTTP response as an HTTP problem object using the default
+// HttpProblemWriter.
+//
+// Errors containing no DDD error are written as a generic Internal Server Error
+func WriteHttpError(w http.ResponseWriter, r *http.Request, err error) {
+	HttpProblemWriter{}.Write(w, r, err)
+}
+
+// Write writes the given error into the HTTP response as an HTTP problem object.
+//
+// HttpProblem is written by default, HttpError is written instead if the writer has the legacy shape enabled.
+// Problem object instance is taken from the request URI, Error challenge (if any) is
+// written as the WWW-Authenticate header and Error retry delay (if any) as the Retry-After header.
+// Nothing is written if err is nil
+func (pw HttpProblemWriter) Write(w http.ResponseWriter, r *http.Request, err error) {
+	if err == nil {
+		return
+	}
+
+	instance := ""
+	if r != nil && r.URL != nil {
+		instance = r.URL.RequestURI()
+	}
+	err = hideInternalError(err, pw.ExposeInternal)
+	w.Header().Set("Content-Type", HttpProblemContentType)
+	if customErr, ok := As(err); ok {
+		if customErr.Challenge() != "" {
+			w.Header().Set("WWW-Authenticate", customErr.Challenge())
+		}
+		if retryAfter := getHttpRetryAfter(customErr.RetryAfter()); retryAfter > 0 {
+			w.Header().Set("Retry-After", strconv.Itoa(retryAfter))
+		}
+	}
+	if pw.Legacy {
+		httpErr := NewHttpError("", instance, err)
+		w.WriteHeader(httpErr.StatusCode)
+		_ = json.NewEncoder(w).Encode(httpErr)
+		return
+	}
+
+	problem := NewHttpProblem("", instance, err)
+	w.WriteHeader(problem.Status)
+	_ = json.NewEncoder(w).Encode(problem)
+}
+
+// Handler returns an HTTP handler calling f and writing the returned error (if any) using the writer
+func (pw HttpProblemWriter) Handler(f HttpHandlerFunc) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := f(w, r); err != nil {
+			pw.Write(w, r, err)
+		}
+	})
+}
+
+// replaces errors containing no DDD error with a generic Internal Server Error unless expose is set
+func hideInternalError(err error, expose bool) error {
+	if _, ok := asGrouped(err); ok || expose {
+		return err
+	}
+	return errors.New(http.StatusText(http.StatusInternalServerError))
+}
+
+// HttpHandlerFunc is an adapter to allow the use of error-returning functions as HTTP handlers.
+//
+// If the function returns an error, it is written into the HTTP response as an HTTP problem object
+type HttpHandlerFunc func(w http.ResponseWriter, r *http.Request) error
+
+var _ http.Handler = HttpHandlerFunc(nil)
+
+// ServeHTTP calls f(w, r) and writes the returned error (if any) using WriteHttpError
+func (f HttpHandlerFunc) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if err := f(w, r); err != nil {
+		WriteHttpError(w, r, err)
+	}
+}
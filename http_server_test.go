@@ -0,0 +1,160 @@
+package ddderr
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var writeHttpErrorTestSuite = []struct {
+	InErr    error
+	InLegacy bool
+	InExpose bool
+	ExpCode  int
+	ExpBody  string
+}{
+	{
+		InErr:   fmt.Errorf("load user: %w", NewNotFound("user")),
+		ExpCode: http.StatusNotFound,
+		ExpBody: `{
+			"type": "about:blank",
+			"title": "Resource not found",
+			"status": 404,
+			"detail": "The resource user was not found",
+			"instance": "/users/123?fields=name",
+			"code": "UserNotFound"
+		}`,
+	},
+	{
+		InErr:    fmt.Errorf("load user: %w", NewNotFound("user")),
+		InLegacy: true,
+		ExpCode:  http.StatusNotFound,
+		ExpBody: `{
+			"type": "Not Found",
+			"title": "Resource not found",
+			"status": "UserNotFound",
+			"status_code": 404,
+			"detail": "The resource user was not found",
+			"instance": "/users/123?fields=name"
+		}`,
+	},
+	{
+		InErr:   errors.New("pq: password authentication failed for user \"admin\""),
+		ExpCode: http.StatusInternalServerError,
+		ExpBody: `{
+			"type": "about:blank",
+			"title": "Internal Server Error",
+			"status": 500,
+			"detail": "Internal Server Error",
+			"instance": "/users/123?fields=name"
+		}`,
+	},
+	{
+		InErr:    errors.New("generic error"),
+		InExpose: true,
+		ExpCode:  http.StatusInternalServerError,
+		ExpBody: `{
+			"type": "about:blank",
+			"title": "generic error",
+			"status": 500,
+			"detail": "generic error",
+			"instance": "/users/123?fields=name"
+		}`,
+	},
+	{
+		InErr:    errors.New("generic error"),
+		InLegacy: true,
+		ExpCode:  http.StatusInternalServerError,
+		ExpBody: `{
+			"type": "Internal Server Error",
+			"title": "Internal Server Error",
+			"status": "Internal Server Error",
+			"status_code": 500,
+			"detail": "Internal Server Error",
+			"instance": "/users/123?fields=name"
+		}`,
+	},
+}
+
+func TestWriteHttpError(t *testing.T) {
+	for _, tt := range writeHttpErrorTestSuite {
+		t.Run("", func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, "/users/123?fields=name", nil)
+			HttpProblemWriter{Legacy: tt.InLegacy, ExposeInternal: tt.InExpose}.Write(rec, req, tt.InErr)
+
+			assert.Equal(t, tt.ExpCode, rec.Code)
+			assert.Equal(t, HttpProblemContentType, rec.Header().Get("Content-Type"))
+			assert.JSONEq(t, tt.ExpBody, rec.Body.String())
+		})
+	}
+
+	rec := httptest.NewRecorder()
+	WriteHttpError(rec, nil, NewUnauthenticated("access_token").SetChallenge(`Bearer realm="example"`))
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	assert.Equal(t, `Bearer realm="example"`, rec.Header().Get("WWW-Authenticate"))
+
+	rec = httptest.NewRecorder()
+	WriteHttpError(rec, nil, fmt.Errorf("send email: %w", NewResourceExhausted("emails", 100, time.Second*30)))
+	assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+	assert.Equal(t, "30", rec.Header().Get("Retry-After"))
+	assert.JSONEq(t, `{
+		"type": "about:blank",
+		"title": "Resource exhausted",
+		"status": 429,
+		"detail": "The quota for resource emails was exhausted, limit is 100, retry after 30s",
+		"code": "EmailsResourceExhausted",
+		"retry_after": 30
+	}`, rec.Body.String())
+
+	rec = httptest.NewRecorder()
+	WriteHttpError(rec, nil, nil)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Zero(t, rec.Body.Len())
+}
+
+func TestHttpHandlerFunc(t *testing.T) {
+	handler := HttpHandlerFunc(func(w http.ResponseWriter, r *http.Request) error {
+		if r.URL.Query().Get("id") == "" {
+			return NewRequired("id")
+		}
+		w.WriteHeader(http.StatusNoContent)
+		return nil
+	})
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/users", nil))
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Equal(t, HttpProblemContentType, rec.Header().Get("Content-Type"))
+	out, err := FromHttpResponse(rec.Result())
+	assert.NoError(t, err)
+	assert.True(t, out.IsRequired())
+	assert.Equal(t, "The property id is required", out.Description())
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/users?id=123", nil))
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+	assert.Empty(t, rec.Header().Get("Content-Type"))
+}
+
+func TestHttpProblemWriter_Handler(t *testing.T) {
+	handler := HttpHandlerFunc(func(w http.ResponseWriter, r *http.Request) error {
+		return errors.New("pq: connection refused")
+	})
+
+	rec := httptest.NewRecorder()
+	HttpProblemWriter{}.Handler(handler).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/users", nil))
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	assert.NotContains(t, rec.Body.String(), "pq: connection refused")
+
+	rec = httptest.NewRecorder()
+	HttpProblemWriter{ExposeInternal: true}.Handler(handler).
+		ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/users", nil))
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	assert.Contains(t, rec.Body.String(), "pq: connection refused")
+}
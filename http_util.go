@@ -2,8 +2,13 @@ package ddderr
 
 import (
 	"net/http"
+	"time"
 )
 
+// HttpStatusClientClosedRequest is the non-standard HTTP status code used when the client closed the request
+// before the server could respond (i.e. canceled operations).
+const HttpStatusClientClosedRequest = 499
+
 // HttpError is an RFC-compliant HTTP protocol problem object.
 //
 // For more information about the fields, please go to: https://datatracker.ietf.org/doc/html/rfc7807
@@ -14,21 +19,60 @@ type HttpError struct {
 	StatusCode int    `json:"status_code,omitempty"`
 	Detail     string `json:"detail,omitempty"`
 	Instance   string `json:"instance,omitempty"`
+	// InvalidParams is an extension member containing every property failure of an Errors collection.
+	//
+	// For more information, go to: https://datatracker.ietf.org/doc/html/rfc7807#section-3
+	InvalidParams []HttpInvalidParam `json:"invalid-params,omitempty"`
+	// RetryAfter is an extension member containing the seconds to wait before retrying the request, useful
+	// to write the Retry-After HTTP header.
+	RetryAfter int `json:"retry_after,omitempty"`
+}
+
+// HttpInvalidParam is a property failure of an HTTP protocol problem object
+type HttpInvalidParam struct {
+	Name   string `json:"name"`
+	Title  string `json:"title,omitempty"`
+	Detail string `json:"detail,omitempty"`
+	Status string `json:"status,omitempty"`
 }
 
-// NewHttpError builds an HttpError from the given DDD error
+// NewHttpError builds an HttpError from the given error.
+//
+// The outermost DDD error found within the error chain is used to populate the HttpError, if none was found,
+// a generic Internal Server Error is returned
 func NewHttpError(errType, instance string, err error) HttpError {
 	if err == nil {
 		return HttpError{}
 	}
 
-	code := http.StatusInternalServerError
-	errHttpType := getHttpErrorType(errType, code)
-
-	customErr, ok := err.(Error)
-	if !ok {
+	target, _ := asGrouped(err)
+	switch customErr := target.(type) {
+	case Error:
+		code := GetHttpStatusCode(customErr)
+		return HttpError{
+			Type:       getHttpErrorType(errType, code),
+			Title:      customErr.Title(),
+			Status:     getHttpDddErrorStatus(customErr, code),
+			StatusCode: code,
+			Detail:     customErr.Description(),
+			Instance:   instance,
+			RetryAfter: getHttpRetryAfter(customErr.RetryAfter()),
+		}
+	case Errors:
+		code := GetHttpStatusCode(customErr)
 		return HttpError{
-			Type:       errHttpType,
+			Type:          getHttpErrorType(errType, code),
+			Title:         customErr.Title(),
+			Status:        customErr.Status(),
+			StatusCode:    code,
+			Detail:        customErr.Description(),
+			Instance:      instance,
+			InvalidParams: newHttpInvalidParams(customErr),
+		}
+	default:
+		code := http.StatusInternalServerError
+		return HttpError{
+			Type:       getHttpErrorType(errType, code),
 			Title:      err.Error(),
 			Status:     http.StatusText(code),
 			StatusCode: code,
@@ -36,17 +80,32 @@ func NewHttpError(errType, instance string, err error) HttpError {
 			Instance:   instance,
 		}
 	}
+}
 
-	code = GetHttpStatusCode(customErr)
-	errHttpType = getHttpErrorType(errType, code)
-	return HttpError{
-		Type:       errHttpType,
-		Title:      customErr.Title(),
-		Status:     getHttpDddErrorStatus(customErr, code),
-		StatusCode: code,
-		Detail:     customErr.Description(),
-		Instance:   instance,
+// builds an HttpInvalidParam for each error of the given collection
+func newHttpInvalidParams(errs Errors) []HttpInvalidParam {
+	params := make([]HttpInvalidParam, 0, len(errs))
+	for _, err := range errs {
+		params = append(params, HttpInvalidParam{
+			Name:   err.Property(),
+			Title:  err.Title(),
+			Detail: err.Description(),
+			Status: err.Status(),
+		})
 	}
+	return params
+}
+
+// retrieves the Retry-After delay in seconds, rounded up
+func getHttpRetryAfter(d time.Duration) int {
+	if d <= 0 {
+		return 0
+	}
+	seconds := d / time.Second
+	if d%time.Second != 0 {
+		seconds++
+	}
+	return int(seconds)
 }
 
 // retrieves a generic HTTP problem object type.
@@ -69,18 +128,53 @@ func getHttpDddErrorStatus(err Error, status int) string {
 	return http.StatusText(status)
 }
 
-// GetHttpStatusCode retrieves an HTTP status code from the given error
-func GetHttpStatusCode(err Error) int {
+// GetHttpStatusCode retrieves an HTTP status code from the outermost DDD error found within the given error chain
+//
+// Note: Returns Internal Server Error (500) if no DDD error was found. Errors of user-defined groups fall back
+// to the group default HTTP status code
+func GetHttpStatusCode(err error) int {
+	target, _ := asGrouped(err)
+	if _, ok := target.(Errors); ok {
+		return http.StatusBadRequest
+	}
+	customErr, ok := target.(Error)
+	if !ok {
+		return http.StatusInternalServerError
+	}
+	if spec, ok := LookupKind(customErr.kind); ok && spec.HTTPStatus != 0 {
+		return spec.HTTPStatus
+	}
+
 	switch {
-	case err.IsAlreadyExists():
+	case customErr.IsUnauthenticated():
+		return http.StatusUnauthorized
+	case customErr.IsPermissionDenied():
+		return http.StatusForbidden
+	case customErr.IsAlreadyExists() || customErr.IsConcurrencyConflict():
 		return http.StatusConflict
-	case err.IsNotFound():
+	case customErr.IsNotFound():
 		return http.StatusNotFound
-	case err.IsInvalidFormat() || err.IsRequired() || err.IsOutOfRange() || err.IsDomain():
+	case customErr.IsFailedPrecondition():
+		return http.StatusPreconditionFailed
+	case customErr.IsInvariantViolation():
+		return http.StatusUnprocessableEntity
+	case customErr.IsInvalidFormat() || customErr.IsRequired() || customErr.IsOutOfRange() ||
+		customErr.IsInvalidLength() || customErr.IsDomain():
 		return http.StatusBadRequest
-	case err.IsRemoteCall():
+	case customErr.IsResourceExhausted():
+		return http.StatusTooManyRequests
+	case customErr.IsUnavailable():
+		return http.StatusServiceUnavailable
+	case customErr.IsRemoteCall():
 		return http.StatusBadGateway
+	case customErr.IsTimeout():
+		return http.StatusGatewayTimeout
+	case customErr.IsCanceled():
+		return HttpStatusClientClosedRequest
 	default:
+		if spec, ok := LookupGroup(customErr.group); ok {
+			return spec.HTTPStatus
+		}
 		return http.StatusInternalServerError
 	}
 }
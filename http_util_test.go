@@ -2,20 +2,38 @@ package ddderr
 
 import (
 	"errors"
+	"fmt"
 	"net/http"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
 
 var getCodeHttpTestSuite = []struct {
-	InErr   Error
+	InErr   error
 	ExpCode int
 }{
+	{
+		InErr:   nil,
+		ExpCode: http.StatusInternalServerError,
+	},
+	{
+		InErr:   errors.New("generic error"),
+		ExpCode: http.StatusInternalServerError,
+	},
 	{
 		InErr:   Error{},
 		ExpCode: http.StatusInternalServerError,
 	},
+	{
+		InErr:   fmt.Errorf("load user: %w", NewNotFound("user")),
+		ExpCode: http.StatusNotFound,
+	},
+	{
+		InErr:   fmt.Errorf("load user: %w", NewRemoteCall("localhost:5432").SetParent(NewNotFound("user"))),
+		ExpCode: http.StatusBadGateway,
+	},
 	{
 		InErr:   NewInfrastructure("generic title", "specific description"),
 		ExpCode: http.StatusInternalServerError,
@@ -40,6 +58,10 @@ var getCodeHttpTestSuite = []struct {
 		InErr:   NewRequired("foo"),
 		ExpCode: http.StatusBadRequest,
 	},
+	{
+		InErr:   NewInvalidLength("foo", 3, 20, 2),
+		ExpCode: http.StatusBadRequest,
+	},
 	{
 		InErr:   NewAlreadyExists("foo"),
 		ExpCode: http.StatusConflict,
@@ -48,6 +70,50 @@ var getCodeHttpTestSuite = []struct {
 		InErr:   NewNotFound("foo"),
 		ExpCode: http.StatusNotFound,
 	},
+	{
+		InErr:   NewConcurrencyConflict("order", 3, 4),
+		ExpCode: http.StatusConflict,
+	},
+	{
+		InErr:   NewFailedPrecondition("order", "order must be paid"),
+		ExpCode: http.StatusPreconditionFailed,
+	},
+	{
+		InErr:   NewInvariantViolation("ORDER_ALREADY_SHIPPED", "order", ""),
+		ExpCode: http.StatusUnprocessableEntity,
+	},
+	{
+		InErr:   NewTimeout("tcp:172.16.52.1", time.Second),
+		ExpCode: http.StatusGatewayTimeout,
+	},
+	{
+		InErr:   NewCanceled("tcp:172.16.52.1"),
+		ExpCode: HttpStatusClientClosedRequest,
+	},
+	{
+		InErr:   NewResourceExhausted("api_calls", 100, time.Second),
+		ExpCode: http.StatusTooManyRequests,
+	},
+	{
+		InErr:   NewUnavailable("tcp:172.16.52.1", time.Second),
+		ExpCode: http.StatusServiceUnavailable,
+	},
+	{
+		InErr:   NewUnauthenticated("access_token"),
+		ExpCode: http.StatusUnauthorized,
+	},
+	{
+		InErr:   NewPermissionDenied("foo", "delete"),
+		ExpCode: http.StatusForbidden,
+	},
+	{
+		InErr:   Errors{}.Append(NewNotFound("foo"), NewRequired("bar")),
+		ExpCode: http.StatusBadRequest,
+	},
+	{
+		InErr:   fmt.Errorf("create user: %w", Errors{}.Append(NewAlreadyExists("foo"))),
+		ExpCode: http.StatusBadRequest,
+	},
 }
 
 func TestGetHttpStatusCode(t *testing.T) {
@@ -156,6 +222,19 @@ var newHttpErrorTestSuite = []struct {
 			Instance:   "/users/12345/msg/abc",
 		},
 	},
+	{
+		InErrType:  "",
+		InInstance: "/users/12345",
+		InErr:      fmt.Errorf("load user: %w", NewNotFound("user")),
+		ExpHttpErr: HttpError{
+			Type:       "Not Found",
+			Title:      "Resource not found",
+			Status:     "UserNotFound",
+			StatusCode: http.StatusNotFound,
+			Detail:     "The resource user was not found",
+			Instance:   "/users/12345",
+		},
+	},
 	{
 		InErrType:  "https://neutrinocorp.org/iam/probs/required",
 		InInstance: "/users/12345/msg/abc",
@@ -195,6 +274,48 @@ var newHttpErrorTestSuite = []struct {
 			Instance:   "",
 		},
 	},
+	{
+		InErrType:  "https://neutrinocorp.org/iam/probs/validation",
+		InInstance: "/users",
+		InErr: fmt.Errorf("create user: %w", Errors{}.Append(NewRequired("name"),
+			NewInvalidFormat("email", "email"))),
+		ExpHttpErr: HttpError{
+			Type:       "https://neutrinocorp.org/iam/probs/validation",
+			Title:      "One or more properties are invalid",
+			Status:     "InvalidProperties",
+			StatusCode: http.StatusBadRequest,
+			Detail:     "The property name is required; The property email has an invalid format, expected [email]",
+			Instance:   "/users",
+			InvalidParams: []HttpInvalidParam{
+				{
+					Name:   "name",
+					Title:  "Missing property",
+					Detail: "The property name is required",
+					Status: "NameIsRequired",
+				},
+				{
+					Name:   "email",
+					Title:  "Property is not a valid format",
+					Detail: "The property email has an invalid format, expected [email]",
+					Status: "EmailInvalidFormat",
+				},
+			},
+		},
+	},
+	{
+		InErrType:  "",
+		InInstance: "/emails",
+		InErr:      NewResourceExhausted("emails", 100, time.Millisecond*1500),
+		ExpHttpErr: HttpError{
+			Type:       "Too Many Requests",
+			Title:      "Resource exhausted",
+			Status:     "EmailsResourceExhausted",
+			StatusCode: http.StatusTooManyRequests,
+			Detail:     "The quota for resource emails was exhausted, limit is 100, retry after 1.5s",
+			Instance:   "/emails",
+			RetryAfter: 2,
+		},
+	},
 	{
 		InErrType:  "https://neutrinocorp.org/iam/probs/generic-infra",
 		InInstance: "",
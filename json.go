@@ -0,0 +1,247 @@
+package ddderr
+
+import (
+	"encoding/json"
+	"errors"
+	"strconv"
+	"time"
+)
+
+// current version of the Error JSON schema
+const jsonSchemaVersion = 1
+
+// jsonError is the JSON transport representation of an Error
+type jsonError struct {
+	Version            int           `json:"version"`
+	Group              string        `json:"group,omitempty"`
+	Kind               string        `json:"kind,omitempty"`
+	Property           string        `json:"property,omitempty"`
+	Title              string        `json:"title,omitempty"`
+	Description        string        `json:"description,omitempty"`
+	Status             string        `json:"status,omitempty"`
+	DynamicDescription bool          `json:"dynamic_description,omitempty"`
+	DynamicStatus      bool          `json:"dynamic_status,omitempty"`
+	LimitA             int           `json:"limit_a,omitempty"`
+	LimitB             int           `json:"limit_b,omitempty"`
+	Formats            []string      `json:"formats,omitempty"`
+	Action             string        `json:"action,omitempty"`
+	Challenge          string        `json:"challenge,omitempty"`
+	ExpectedVersion    int64         `json:"expected_version,omitempty"`
+	ActualVersion      int64         `json:"actual_version,omitempty"`
+	Reason             string        `json:"reason,omitempty"`
+	Duration           time.Duration `json:"duration,omitempty"`
+	RetryAfter         time.Duration `json:"retry_after,omitempty"`
+	Range              *jsonRange    `json:"range,omitempty"`
+	Length             int           `json:"length,omitempty"`
+	RuleID             string        `json:"rule_id,omitempty"`
+	Parent             string        `json:"parent,omitempty"`
+}
+
+var (
+	_ json.Marshaler   = Error{}
+	_ json.Unmarshaler = &Error{}
+)
+
+// MarshalJSON encodes the Error using a versioned JSON schema, useful to transport errors between services.
+//
+// Parent is encoded using its error message while stack trace is never encoded
+func (e Error) MarshalJSON() ([]byte, error) {
+	v := jsonError{
+		Version:            jsonSchemaVersion,
+		Group:              e.group,
+		Kind:               e.kind,
+		Property:           e.property,
+		Title:              e.title,
+		Description:        e.description,
+		Status:             e.statusName,
+		DynamicDescription: e.dynamicDescription,
+		DynamicStatus:      e.dynamicStatus,
+		LimitA:             e.limitA,
+		LimitB:             e.limitB,
+		Formats:            e.formats,
+		Action:             e.action,
+		Challenge:          e.challenge,
+		ExpectedVersion:    e.expectedVersion,
+		ActualVersion:      e.actualVersion,
+		Reason:             e.reason,
+		Duration:           e.duration,
+		RetryAfter:         e.retryAfter,
+		Length:             e.length,
+		RuleID:             e.ruleID,
+	}
+	if e.parent != nil {
+		v.Parent = e.parent.Error()
+	}
+	if e.rangeValue != nil {
+		r, err := newJSONRange(e.rangeValue, e.lowerBound, e.upperBound)
+		if err != nil {
+			return nil, err
+		}
+		v.Range = &r
+	}
+	return json.Marshal(v)
+}
+
+// UnmarshalJSON decodes an Error previously encoded with MarshalJSON
+//
+// Note: Parent is decoded as a generic error containing the original parent message
+func (e *Error) UnmarshalJSON(data []byte) error {
+	var v jsonError
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	if v.Version != jsonSchemaVersion {
+		return errors.New("ddderr: unsupported error schema version " + strconv.Itoa(v.Version))
+	}
+
+	*e = Error{
+		group:              v.Group,
+		kind:               v.Kind,
+		property:           v.Property,
+		title:              v.Title,
+		description:        v.Description,
+		statusName:         v.Status,
+		dynamicDescription: v.DynamicDescription,
+		dynamicStatus:      v.DynamicStatus,
+		limitA:             v.LimitA,
+		limitB:             v.LimitB,
+		formats:            v.Formats,
+		action:             v.Action,
+		challenge:          v.Challenge,
+		expectedVersion:    v.ExpectedVersion,
+		actualVersion:      v.ActualVersion,
+		reason:             v.Reason,
+		duration:           v.Duration,
+		retryAfter:         v.RetryAfter,
+		length:             v.Length,
+		ruleID:             v.RuleID,
+	}
+	if v.Parent != "" {
+		e.parent = errors.New(v.Parent)
+	}
+	if v.Range != nil {
+		return v.Range.decode(e)
+	}
+	return nil
+}
+
+// range value types
+const (
+	jsonRangeFloat    = "float"
+	jsonRangeDuration = "duration"
+	jsonRangeTime     = "time"
+)
+
+// jsonRange is the JSON transport representation of a typed range
+type jsonRange struct {
+	Type  string          `json:"type"`
+	Value json.RawMessage `json:"value"`
+	Lower *jsonBound      `json:"lower,omitempty"`
+	Upper *jsonBound      `json:"upper,omitempty"`
+}
+
+type jsonBound struct {
+	Type  string          `json:"type"`
+	Value json.RawMessage `json:"value"`
+}
+
+func newJSONRange(value interface{}, lower, upper Bound) (jsonRange, error) {
+	var r jsonRange
+	switch value.(type) {
+	case float64:
+		r.Type = jsonRangeFloat
+	case time.Duration:
+		r.Type = jsonRangeDuration
+	case time.Time:
+		r.Type = jsonRangeTime
+	default:
+		return jsonRange{}, errors.New("ddderr: unsupported range value type")
+	}
+
+	var err error
+	if r.Value, err = encodeJSONRangeValue(value); err != nil {
+		return jsonRange{}, err
+	}
+	if r.Lower, err = newJSONBound(lower); err != nil {
+		return jsonRange{}, err
+	}
+	if r.Upper, err = newJSONBound(upper); err != nil {
+		return jsonRange{}, err
+	}
+	return r, nil
+}
+
+func newJSONBound(b Bound) (*jsonBound, error) {
+	if b.Type == Unbounded {
+		return nil, nil
+	}
+	value, err := encodeJSONRangeValue(b.Value)
+	if err != nil {
+		return nil, err
+	}
+	return &jsonBound{Type: b.Type.String(), Value: value}, nil
+}
+
+// encodes a range value, floats are encoded as strings as JSON numbers cannot hold non-finite values
+// (e.g. +Inf, NaN)
+func encodeJSONRangeValue(value interface{}) (json.RawMessage, error) {
+	if v, ok := value.(float64); ok {
+		return json.Marshal(strconv.FormatFloat(v, 'g', -1, 64))
+	}
+	return json.Marshal(value)
+}
+
+// decodes the range into the given Error
+func (r jsonRange) decode(e *Error) error {
+	var err error
+	if e.rangeValue, err = r.decodeValue(r.Value); err != nil {
+		return err
+	}
+	if e.lowerBound, err = r.decodeBound(r.Lower); err != nil {
+		return err
+	}
+	e.upperBound, err = r.decodeBound(r.Upper)
+	return err
+}
+
+func (r jsonRange) decodeBound(b *jsonBound) (Bound, error) {
+	if b == nil {
+		return Bound{}, nil
+	}
+
+	var t BoundType
+	switch b.Type {
+	case Inclusive.String():
+		t = Inclusive
+	case Exclusive.String():
+		t = Exclusive
+	default:
+		return Bound{}, errors.New("ddderr: unsupported bound type " + b.Type)
+	}
+	value, err := r.decodeValue(b.Value)
+	if err != nil {
+		return Bound{}, err
+	}
+	return Bound{Type: t, Value: value}, nil
+}
+
+func (r jsonRange) decodeValue(data json.RawMessage) (interface{}, error) {
+	switch r.Type {
+	case jsonRangeFloat:
+		var s string
+		if err := json.Unmarshal(data, &s); err != nil {
+			return nil, err
+		}
+		return strconv.ParseFloat(s, 64)
+	case jsonRangeDuration:
+		var v time.Duration
+		err := json.Unmarshal(data, &v)
+		return v, err
+	case jsonRangeTime:
+		var v time.Time
+		err := json.Unmarshal(data, &v)
+		return v, err
+	default:
+		return nil, errors.New("ddderr: unsupported range type " + r.Type)
+	}
+}
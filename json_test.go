@@ -0,0 +1,134 @@
+package ddderr
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var jsonTestSuite = []struct {
+	In Error
+}{
+	{
+		In: Error{},
+	},
+	{
+		In: NewDomain("generic title", "specific description").SetStatus("GenericError"),
+	},
+	{
+		In: NewInfrastructure("generic title", "specific description").
+			SetParent(errors.New("sarama: Apache kafka consumer error")),
+	},
+	{
+		In: NewRemoteCall("localhost:5432"),
+	},
+	{
+		In: NewNotFound("foo"),
+	},
+	{
+		In: NewNotFound("foo").SetProperty("bar"),
+	},
+	{
+		In: NewAlreadyExists("foo"),
+	},
+	{
+		In: NewOutOfRange("foo", 8, 256).SetProperty("bar"),
+	},
+	{
+		In: NewInvalidFormat("foo", "jpeg", "gif").SetProperty("bar"),
+	},
+	{
+		In: NewRequired("foo").SetDescription("custom description"),
+	},
+	{
+		In: NewUnauthenticated("access_token").SetChallenge(`Bearer realm="example"`),
+	},
+	{
+		In: NewPermissionDenied("foo", "delete").SetProperty("bar"),
+	},
+	{
+		In: NewConcurrencyConflict("order", 3, 4).SetProperty("cart"),
+	},
+	{
+		In: NewTimeout("localhost:5432", time.Millisecond*250).SetProperty("localhost:6379"),
+	},
+	{
+		In: NewCanceled("localhost:5432"),
+	},
+	{
+		In: NewInvalidLength("username", 3, 20, 2).SetProperty("nickname"),
+	},
+	{
+		In: NewResourceExhausted("api_calls", 100, time.Second*30),
+	},
+	{
+		In: NewUnavailable("localhost:5432", time.Second*30),
+	},
+	{
+		In: NewFailedPrecondition("order", "order must be paid").SetProperty("cart"),
+	},
+	{
+		In: NewInvariantViolation("ORDER_ALREADY_SHIPPED", "order", "an order cannot be shipped twice"),
+	},
+}
+
+func TestError_JSON(t *testing.T) {
+	for _, tt := range jsonTestSuite {
+		t.Run("", func(t *testing.T) {
+			data, err := json.Marshal(tt.In)
+			assert.NoError(t, err)
+
+			var out Error
+			assert.NoError(t, json.Unmarshal(data, &out))
+			if tt.In.Parent() != nil {
+				assert.EqualError(t, out.Parent(), tt.In.Parent().Error())
+				out.parent = tt.In.Parent()
+			}
+			assert.EqualValues(t, tt.In, out)
+			assert.Equal(t, tt.In.Description(), out.Description())
+			assert.Equal(t, tt.In.Status(), out.Status())
+			assert.Equal(t, tt.In.Title(), out.Title())
+			assert.Equal(t, tt.In.IsNotFound(), out.IsNotFound())
+			assert.Equal(t, tt.In.IsDomain(), out.IsDomain())
+		})
+	}
+}
+
+func TestError_MarshalJSON(t *testing.T) {
+	data, err := json.Marshal(NewOutOfRange("foo", 8, 256).SetParent(errors.New("generic error")))
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{
+		"version": 1,
+		"group": "Domain",
+		"kind": "OutOfRange",
+		"property": "foo",
+		"title": "Property is out of the specified range",
+		"description": "The property foo is out of range [8,256)",
+		"status": "FooOutOfRange",
+		"limit_a": 8,
+		"limit_b": 256,
+		"parent": "generic error"
+	}`, string(data))
+}
+
+func TestError_UnmarshalJSON(t *testing.T) {
+	var err Error
+	assert.EqualError(t, json.Unmarshal([]byte(`{"version":2,"kind":"NotFound"}`), &err),
+		"ddderr: unsupported error schema version 2")
+	assert.Error(t, json.Unmarshal([]byte(`{"version":"1"}`), &err))
+	assert.EqualError(t, json.Unmarshal([]byte(`{"version":1,"range":{"type":"int","value":1}}`), &err),
+		"ddderr: unsupported range type int")
+	assert.EqualError(t, json.Unmarshal(
+		[]byte(`{"version":1,"range":{"type":"float","value":"1","lower":{"type":"foo","value":"0"}}}`), &err),
+		"ddderr: unsupported bound type foo")
+
+	var errs Errors
+	data, _ := json.Marshal(Errors{}.Append(NewRequired("name"), NewNotFound("country")))
+	assert.NoError(t, json.Unmarshal(data, &errs))
+	assert.Len(t, errs, 2)
+	assert.True(t, errs[0].IsRequired())
+	assert.Equal(t, "The resource country was not found", errs[1].Description())
+}
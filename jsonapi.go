@@ -0,0 +1,193 @@
+package ddderr
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// JSONAPIContentType is the media type of a JSON:API document.
+//
+// For more information, go to: https://jsonapi.org/format/#content-negotiation
+const JSONAPIContentType = "application/vnd.api+json"
+
+// JSONAPIErrorDocument is a JSON:API top-level document containing error objects.
+//
+// For more information about the fields, please go to: https://jsonapi.org/format/#errors
+type JSONAPIErrorDocument struct {
+	Errors []JSONAPIError `json:"errors"`
+}
+
+// JSONAPIError is a JSON:API error object.
+//
+// Status is the HTTP status code as a string, Code is the Error status name and Meta contains the Error group
+// and kind
+type JSONAPIError struct {
+	ID     string                 `json:"id,omitempty"`
+	Status string                 `json:"status,omitempty"`
+	Code   string                 `json:"code,omitempty"`
+	Title  string                 `json:"title,omitempty"`
+	Detail string                 `json:"detail,omitempty"`
+	Source *JSONAPIErrorSource    `json:"source,omitempty"`
+	Meta   map[string]interface{} `json:"meta,omitempty"`
+}
+
+// JSONAPIErrorSource references the primary source of a JSON:API error object
+type JSONAPIErrorSource struct {
+	Pointer   string `json:"pointer,omitempty"`
+	Parameter string `json:"parameter,omitempty"`
+}
+
+var _ error = JSONAPIError{}
+
+// Error returns the JSON:API error object detail, title is returned if detail is empty
+func (e JSONAPIError) Error() string {
+	if e.Detail != "" {
+		return e.Detail
+	}
+	return e.Title
+}
+
+// NewJSONAPIErrors builds a JSON:API errors document from the given errors.
+//
+// The outermost DDD error found within each error chain is used to populate an error object and Errors
+// collections are expanded into one error object per property. Error property is set as a JSON pointer to the
+// request document attributes (e.g. address.street becomes /data/attributes/address/street), properties
+// already starting with a slash are used as is. Nil errors are ignored
+func NewJSONAPIErrors(errs ...error) JSONAPIErrorDocument {
+	return newJSONAPIErrors(false, errs)
+}
+
+// NewJSONAPIParameterErrors builds a JSON:API errors document from the given errors, useful when the errors
+// were caused by URI query parameters.
+//
+// Same as NewJSONAPIErrors but Error property is set as the source parameter instead
+func NewJSONAPIParameterErrors(errs ...error) JSONAPIErrorDocument {
+	return newJSONAPIErrors(true, errs)
+}
+
+func newJSONAPIErrors(isParameter bool, errs []error) JSONAPIErrorDocument {
+	doc := JSONAPIErrorDocument{Errors: make([]JSONAPIError, 0, len(errs))}
+	for _, err := range errs {
+		if err == nil {
+			continue
+		}
+
+		target, _ := asGrouped(err)
+		switch customErr := target.(type) {
+		case Error:
+			doc.Errors = append(doc.Errors, newJSONAPIError(isParameter, customErr))
+		case Errors:
+			for _, e := range customErr {
+				doc.Errors = append(doc.Errors, newJSONAPIError(isParameter, e))
+			}
+		default:
+			doc.Errors = append(doc.Errors, JSONAPIError{
+				Status: strconv.Itoa(http.StatusInternalServerError),
+				Title:  err.Error(),
+				Detail: err.Error(),
+			})
+		}
+	}
+	return doc
+}
+
+func newJSONAPIError(isParameter bool, err Error) JSONAPIError {
+	apiErr := JSONAPIError{
+		Status: strconv.Itoa(GetHttpStatusCode(err)),
+		Code:   err.Status(),
+		Title:  err.Title(),
+		Detail: err.Description(),
+		Meta: map[string]interface{}{
+			"group": err.Group(),
+			"kind":  err.Kind(),
+		},
+	}
+	if retryAfter := getHttpRetryAfter(err.RetryAfter()); retryAfter > 0 {
+		apiErr.Meta["retry_after"] = retryAfter
+	}
+	switch {
+	case err.Property() == "":
+	case isParameter:
+		apiErr.Source = &JSONAPIErrorSource{Parameter: err.Property()}
+	default:
+		apiErr.Source = &JSONAPIErrorSource{Pointer: newJSONAPIPointer(err.Property())}
+	}
+	return apiErr
+}
+
+// builds a JSON pointer to the request document attributes from the given property.
+//
+// For more information, go to: https://datatracker.ietf.org/doc/html/rfc6901
+func newJSONAPIPointer(property string) string {
+	if strings.HasPrefix(property, "/") {
+		return property
+	}
+	segments := strings.Split(property, ".")
+	for i, segment := range segments {
+		segments[i] = strings.NewReplacer("~", "~0", "/", "~1").Replace(segment)
+	}
+	return "/data/attributes/" + strings.Join(segments, "/")
+}
+
+// StatusCode retrieves the most generally applicable HTTP status code of the document.
+//
+// Returns the status code shared by every error object, otherwise Bad Request (400) if every error object is a
+// client error or Internal Server Error (500) if not
+func (d JSONAPIErrorDocument) StatusCode() int {
+	code := 0
+	for _, err := range d.Errors {
+		errCode, _ := strconv.Atoi(err.Status)
+		switch {
+		case code == 0 || code == errCode:
+			code = errCode
+		case errCode >= http.StatusInternalServerError || code >= http.StatusInternalServerError:
+			return http.StatusInternalServerError
+		default:
+			code = http.StatusBadRequest
+		}
+	}
+	if code == 0 {
+		return http.StatusInternalServerError
+	}
+	return code
+}
+
+// JSONAPIErrorWriter writes errors into HTTP responses as JSON:API errors documents.
+//
+// The zero value hides the message of errors containing no DDD error, WriteJSONAPIErrors uses the zero value
+type JSONAPIErrorWriter struct {
+	// ExposeInternal writes the message of errors containing no DDD error instead of a generic Internal Server
+	// Error message, such messages might leak infrastructure details (e.g. SQL errors, hostnames).
+	ExposeInternal bool
+}
+
+// WriteJSONAPIErrors writes the given errors into the HTTP response as a JSON:API errors document using the
+// default JSONAPIErrorWriter.
+//
+// Errors containing no DDD error are written as a generic Internal Server Error
+func WriteJSONAPIErrors(w http.ResponseWriter, errs ...error) {
+	JSONAPIErrorWriter{}.Write(w, errs...)
+}
+
+// Write writes the given errors into the HTTP response as a JSON:API errors document.
+//
+// Response status code is the most generally applicable status code of the document. Nothing is written if
+// every error is nil
+func (jw JSONAPIErrorWriter) Write(w http.ResponseWriter, errs ...error) {
+	hidden := make([]error, 0, len(errs))
+	for _, err := range errs {
+		if err != nil {
+			hidden = append(hidden, hideInternalError(err, jw.ExposeInternal))
+		}
+	}
+	doc := NewJSONAPIErrors(hidden...)
+	if len(doc.Errors) == 0 {
+		return
+	}
+
+	w.Header().Set("Content-Type", JSONAPIContentType)
+	w.WriteHeader(doc.StatusCode())
+	_ = json.NewEncoder(w).Encode(doc)
+}
@@ -0,0 +1,158 @@
+package ddderr
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var newJSONAPIErrorsTestSuite = []struct {
+	InErrs  []error
+	ExpBody string
+	ExpCode int
+}{
+	{
+		InErrs:  []error{nil},
+		ExpBody: `{"errors": []}`,
+		ExpCode: http.StatusInternalServerError,
+	},
+	{
+		InErrs: []error{errors.New("generic error")},
+		ExpBody: `{
+			"errors": [{"status": "500", "title": "generic error", "detail": "generic error"}]
+		}`,
+		ExpCode: http.StatusInternalServerError,
+	},
+	{
+		InErrs: []error{fmt.Errorf("get user: %w", NewNotFound("user"))},
+		ExpBody: `{
+			"errors": [
+				{
+					"status": "404",
+					"code": "UserNotFound",
+					"title": "Resource not found",
+					"detail": "The resource user was not found",
+					"source": {"pointer": "/data/attributes/user"},
+					"meta": {"group": "Domain", "kind": "NotFound"}
+				}
+			]
+		}`,
+		ExpCode: http.StatusNotFound,
+	},
+	{
+		InErrs: []error{
+			Errors{NewRequired("address.street"), NewInvalidLength("/data/id", 3, 20, 2)},
+			NewAlreadyExists("email"),
+		},
+		ExpBody: `{
+			"errors": [
+				{
+					"status": "400",
+					"code": "AddressStreetIsRequired",
+					"title": "Missing property",
+					"detail": "The property address.street is required",
+					"source": {"pointer": "/data/attributes/address/street"},
+					"meta": {"group": "Domain", "kind": "Required"}
+				},
+				{
+					"status": "400",
+					"code": "DataIdTooShort",
+					"title": "Property has an invalid length",
+					"detail": "The property /data/id is too short, minimum length is 3, got 2",
+					"source": {"pointer": "/data/id"},
+					"meta": {"group": "Domain", "kind": "InvalidLength"}
+				},
+				{
+					"status": "409",
+					"code": "EmailAlreadyExists",
+					"title": "Resource already exists",
+					"detail": "The resource email already exists",
+					"source": {"pointer": "/data/attributes/email"},
+					"meta": {"group": "Domain", "kind": "AlreadyExists"}
+				}
+			]
+		}`,
+		ExpCode: http.StatusBadRequest,
+	},
+	{
+		InErrs: []error{NewRequired("name"), NewUnavailable("", 2*time.Second)},
+		ExpBody: `{
+			"errors": [
+				{
+					"status": "400",
+					"code": "NameIsRequired",
+					"title": "Missing property",
+					"detail": "The property name is required",
+					"source": {"pointer": "/data/attributes/name"},
+					"meta": {"group": "Domain", "kind": "Required"}
+				},
+				{
+					"status": "503",
+					"code": "Unavailable",
+					"title": "Service unavailable",
+					"detail": "External resource is unavailable, retry after 2s",
+					"meta": {"group": "Infrastructure", "kind": "Unavailable", "retry_after": 2}
+				}
+			]
+		}`,
+		ExpCode: http.StatusInternalServerError,
+	},
+}
+
+func TestNewJSONAPIErrors(t *testing.T) {
+	for _, tt := range newJSONAPIErrorsTestSuite {
+		t.Run("", func(t *testing.T) {
+			doc := NewJSONAPIErrors(tt.InErrs...)
+			data, err := json.Marshal(doc)
+			assert.NoError(t, err)
+			assert.JSONEq(t, tt.ExpBody, string(data))
+			assert.Equal(t, tt.ExpCode, doc.StatusCode())
+		})
+	}
+}
+
+func TestNewJSONAPIParameterErrors(t *testing.T) {
+	doc := NewJSONAPIParameterErrors(NewInvalidFormat("page[size]", "integer"))
+	assert.Len(t, doc.Errors, 1)
+	assert.Equal(t, &JSONAPIErrorSource{Parameter: "page[size]"}, doc.Errors[0].Source)
+	assert.Equal(t, "400", doc.Errors[0].Status)
+}
+
+func TestWriteJSONAPIErrors(t *testing.T) {
+	rec := httptest.NewRecorder()
+	WriteJSONAPIErrors(rec, nil)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Empty(t, rec.Body.String())
+
+	rec = httptest.NewRecorder()
+	WriteJSONAPIErrors(rec, NewNotFound("user"))
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	assert.Equal(t, JSONAPIContentType, rec.Header().Get("Content-Type"))
+
+	var doc JSONAPIErrorDocument
+	assert.NoError(t, json.NewDecoder(rec.Body).Decode(&doc))
+	assert.Len(t, doc.Errors, 1)
+	assert.Equal(t, "UserNotFound", doc.Errors[0].Code)
+	assert.Equal(t, "The resource user was not found", doc.Errors[0].Error())
+
+	rec = httptest.NewRecorder()
+	WriteJSONAPIErrors(rec, NewRequired("name"), errors.New("pq: password authentication failed"))
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	assert.NotContains(t, rec.Body.String(), "pq: password authentication failed")
+	doc = JSONAPIErrorDocument{}
+	assert.NoError(t, json.NewDecoder(rec.Body).Decode(&doc))
+	assert.Len(t, doc.Errors, 2)
+	assert.Equal(t, "Internal Server Error", doc.Errors[1].Title)
+	assert.Equal(t, "Internal Server Error", doc.Errors[1].Detail)
+
+	rec = httptest.NewRecorder()
+	JSONAPIErrorWriter{ExposeInternal: true}.Write(rec, errors.New("pq: password authentication failed"))
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	assert.Contains(t, rec.Body.String(), "pq: password authentication failed")
+}
@@ -0,0 +1,254 @@
+package ddderr
+
+import (
+	"errors"
+	"strings"
+	"sync"
+)
+
+// KindSpec describes a user-defined error kind.
+//
+// Registered kinds get the same dynamic description, status name and protocol mapping behavior as the
+// built-in ones
+type KindSpec struct {
+	// Name is the unique kind name (e.g. InsufficientFunds)
+	Name string
+	// Parent is the kind specialised by this kind (e.g. NotFound for SkuNotFound), it must be either a
+	// built-in kind or a registered one.
+	//
+	// Errors of this kind satisfy every check of its ancestors (e.g. IsNotFound) and inherit the unset
+	// attributes of its parent
+	Parent string
+	// Group is either a built-in group or a registered one, defaults to the parent group or GroupDomain
+	Group string
+	// Title is the generic error message of the kind, defaults to the parent title or Name
+	Title string
+	// DescriptionFunc builds the specific error message from the error property, defaults to the parent
+	// description or Title
+	DescriptionFunc func(property string) string
+	// StatusSuffix is appended to the sanitized property to build the status name, defaults to Name
+	StatusSuffix string
+	// HTTPStatus is the HTTP status code of the kind, defaults to the parent HTTP status code or the group
+	// default
+	HTTPStatus int
+}
+
+// builtinKinds contains every kind owned by this package along its sentinel, they cannot be registered
+var builtinKinds = map[string]Error{
+	notFound:              ErrNotFound,
+	alreadyExists:         ErrAlreadyExists,
+	outOfRange:            ErrOutOfRange,
+	invalidFormat:         ErrInvalidFormat,
+	required:              ErrRequired,
+	remoteCall:            ErrRemoteCall,
+	unauthenticated:       ErrUnauthenticated,
+	permissionDenied:      ErrPermissionDenied,
+	concurrencyConflict:   ErrConcurrencyConflict,
+	failedPrecondition:    ErrFailedPrecondition,
+	timeout:               ErrTimeout,
+	canceled:              ErrCanceled,
+	resourceExhausted:     ErrResourceExhausted,
+	unavailable:           ErrUnavailable,
+	invalidLength:         ErrInvalidLength,
+	invariantViolation:    ErrInvariantViolation,
+	unknownDomain:         ErrDomain,
+	unknownInfrastructure: ErrInfrastructure,
+}
+
+// kindEntry is a registered kind along its nearest built-in ancestor used to build descriptions
+type kindEntry struct {
+	spec        KindSpec
+	builtinRoot string
+}
+
+// kindRegistry is a concurrency-safe store of user-defined kinds
+type kindRegistry struct {
+	mu    sync.RWMutex
+	kinds map[string]kindEntry
+}
+
+var kinds = &kindRegistry{kinds: map[string]kindEntry{}}
+
+// RegisterKind registers a user-defined error kind, registering an existing user-defined kind replaces it.
+//
+// Attributes inherited from the parent kind are resolved at registration time. Returns an error if name is
+// empty, name belongs to a built-in kind, parent is unknown, parent creates a cycle or group is unknown
+func RegisterKind(spec KindSpec) error {
+	if spec.Name == "" {
+		return errors.New("ddderr: kind name is required")
+	}
+	if _, ok := builtinKinds[spec.Name]; ok {
+		return errors.New("ddderr: kind " + spec.Name + " is built-in")
+	}
+	if _, ok := lookupGroup(spec.Group); !ok && spec.Group != "" {
+		return errors.New("ddderr: unknown group " + spec.Group)
+	}
+	if spec.StatusSuffix == "" {
+		spec.StatusSuffix = spec.Name
+	}
+	entry := kindEntry{spec: spec}
+	if sentinel, ok := builtinKinds[spec.Parent]; ok {
+		entry = inheritBuiltinKind(spec, sentinel)
+	}
+
+	kinds.mu.Lock()
+	defer kinds.mu.Unlock()
+	if _, ok := builtinKinds[spec.Parent]; !ok && spec.Parent != "" {
+		parent, ok := kinds.kinds[spec.Parent]
+		if !ok {
+			return errors.New("ddderr: unknown parent kind " + spec.Parent)
+		}
+		if kinds.isDescendant(spec.Parent, spec.Name) {
+			return errors.New("ddderr: kind " + spec.Name + " cannot descend from itself")
+		}
+		entry = inheritKind(spec, parent)
+	}
+	entry.spec = setKindDefaults(entry.spec)
+	kinds.kinds[spec.Name] = entry
+	return nil
+}
+
+// fills the unset attributes of the given kind using a built-in parent kind
+func inheritBuiltinKind(spec KindSpec, parent Error) kindEntry {
+	if spec.Group == "" {
+		spec.Group = parent.group
+	}
+	if spec.Title == "" {
+		spec.Title = parent.title
+	}
+	if spec.HTTPStatus == 0 {
+		spec.HTTPStatus = GetHttpStatusCode(parent)
+	}
+	root := ""
+	if spec.DescriptionFunc == nil {
+		root = parent.kind
+	}
+	return kindEntry{spec: spec, builtinRoot: root}
+}
+
+// fills the unset attributes of the given kind using a registered parent kind
+func inheritKind(spec KindSpec, parent kindEntry) kindEntry {
+	if spec.Group == "" {
+		spec.Group = parent.spec.Group
+	}
+	if spec.Title == "" {
+		spec.Title = parent.spec.Title
+	}
+	if spec.HTTPStatus == 0 {
+		spec.HTTPStatus = parent.spec.HTTPStatus
+	}
+	root := ""
+	if spec.DescriptionFunc == nil {
+		spec.DescriptionFunc = parent.spec.DescriptionFunc
+		root = parent.builtinRoot
+	}
+	return kindEntry{spec: spec, builtinRoot: root}
+}
+
+func setKindDefaults(spec KindSpec) KindSpec {
+	if spec.Group == "" {
+		spec.Group = domain
+	}
+	if spec.Title == "" {
+		spec.Title = spec.Name
+	}
+	return spec
+}
+
+// checks if kind is (or descends from) the given ancestor, registry lock must be held
+func (r *kindRegistry) isDescendant(kind, ancestor string) bool {
+	for kind != "" {
+		if kind == ancestor {
+			return true
+		}
+		entry, ok := r.kinds[kind]
+		if !ok {
+			return false
+		}
+		kind = entry.spec.Parent
+	}
+	return false
+}
+
+// LookupKind retrieves the specification of a user-defined kind
+func LookupKind(name string) (KindSpec, bool) {
+	entry, ok := lookupKind(name)
+	return entry.spec, ok
+}
+
+func lookupKind(name string) (kindEntry, bool) {
+	if _, ok := builtinKinds[name]; ok {
+		return kindEntry{}, false
+	}
+	kinds.mu.RLock()
+	entry, ok := kinds.kinds[name]
+	kinds.mu.RUnlock()
+	return entry, ok
+}
+
+// checks if the given kind is (or descends from) the given ancestor kind
+func isKind(kind, ancestor string) bool {
+	if kind == ancestor {
+		return true
+	}
+	if _, ok := builtinKinds[kind]; ok || kind == "" || ancestor == "" {
+		return false
+	}
+	kinds.mu.RLock()
+	defer kinds.mu.RUnlock()
+	return kinds.isDescendant(kind, ancestor)
+}
+
+// retrieves the user-defined kind whose HTTP status code and status suffix match the given ones
+func lookupKindByHttpStatus(code int, statusName string) (KindSpec, bool) {
+	kinds.mu.RLock()
+	defer kinds.mu.RUnlock()
+	var match KindSpec
+	found := false
+	for _, entry := range kinds.kinds {
+		spec := entry.spec
+		if spec.HTTPStatus != code || !strings.HasSuffix(statusName, spec.StatusSuffix) {
+			continue
+		}
+		// longest suffix wins to keep results deterministic
+		if !found || len(spec.StatusSuffix) > len(match.StatusSuffix) ||
+			(len(spec.StatusSuffix) == len(match.StatusSuffix) && spec.Name < match.Name) {
+			match = spec
+			found = true
+		}
+	}
+	return match, found
+}
+
+// NewKind creates an Error of a user-defined kind for the given property.
+//
+// Note: Unregistered kinds are built as Domain errors using the kind name as title
+func NewKind(kind, property string) Error {
+	entry, ok := lookupKind(kind)
+	if !ok {
+		entry = kindEntry{spec: KindSpec{Name: kind, Group: domain, Title: kind, StatusSuffix: kind}}
+	}
+	return Error{
+		parent:      nil,
+		group:       entry.spec.Group,
+		kind:        kind,
+		property:    property,
+		title:       entry.spec.Title,
+		description: newKindDescription(entry, property),
+		statusName:  getSanitizedStatusName(property, entry.spec.StatusSuffix),
+		stack:       callers(1),
+	}
+}
+
+func newKindDescription(entry kindEntry, property string) string {
+	if entry.spec.DescriptionFunc != nil {
+		return entry.spec.DescriptionFunc(property)
+	}
+	if entry.builtinRoot != "" {
+		root := Error{kind: entry.builtinRoot, property: property, dynamicDescription: true}
+		if desc := root.Description(); desc != "" {
+			return desc
+		}
+	}
+	return entry.spec.Title
+}
@@ -0,0 +1,191 @@
+package ddderr
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var registerKindTestSuite = []struct {
+	In     KindSpec
+	ExpErr bool
+}{
+	{
+		In:     KindSpec{},
+		ExpErr: true,
+	},
+	{
+		In:     KindSpec{Name: notFound},
+		ExpErr: true,
+	},
+	{
+		In:     KindSpec{Name: "TestUnknownGroupKind", Group: "TestUnknownGroup"},
+		ExpErr: true,
+	},
+	{
+		In:     KindSpec{Name: "TestUnknownParentKind", Parent: "TestMissingKind"},
+		ExpErr: true,
+	},
+	{
+		In:     KindSpec{Name: "TestDefaultsKind"},
+		ExpErr: false,
+	},
+	{
+		In:     KindSpec{Name: "TestInfrastructureKind", Group: GroupInfrastructure},
+		ExpErr: false,
+	},
+}
+
+func TestRegisterKind(t *testing.T) {
+	for _, tt := range registerKindTestSuite {
+		t.Run(tt.In.Name, func(t *testing.T) {
+			err := RegisterKind(tt.In)
+			assert.Equal(t, tt.ExpErr, err != nil)
+			_, ok := LookupKind(tt.In.Name)
+			assert.Equal(t, !tt.ExpErr, ok)
+		})
+	}
+
+	spec, _ := LookupKind("TestDefaultsKind")
+	assert.Equal(t, KindSpec{
+		Name:         "TestDefaultsKind",
+		Group:        GroupDomain,
+		Title:        "TestDefaultsKind",
+		StatusSuffix: "TestDefaultsKind",
+	}, spec)
+}
+
+func TestRegisterKind_Concurrent(t *testing.T) {
+	var wg sync.WaitGroup
+	for i := 0; i < 16; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			name := "TestConcurrentKind" + strconv.Itoa(i%4)
+			assert.NoError(t, RegisterKind(KindSpec{Name: name, HTTPStatus: http.StatusTeapot}))
+			err := NewKind(name, "foo")
+			assert.Equal(t, "Foo"+name, err.Status())
+			assert.Equal(t, http.StatusTeapot, GetHttpStatusCode(err))
+		}(i)
+	}
+	wg.Wait()
+}
+
+func TestNewKind(t *testing.T) {
+	assert.NoError(t, RegisterKind(KindSpec{
+		Name:  "InsufficientFunds",
+		Title: "Insufficient funds",
+		DescriptionFunc: func(property string) string {
+			if property == "" {
+				return "insufficient funds"
+			}
+			return "The account " + property + " has insufficient funds"
+		},
+		StatusSuffix: "HasInsufficientFunds",
+		HTTPStatus:   http.StatusPaymentRequired,
+	}))
+
+	err := NewKind("InsufficientFunds", "savings")
+	assert.Equal(t, "InsufficientFunds", err.Kind())
+	assert.True(t, err.IsKind("InsufficientFunds"))
+	assert.True(t, err.IsDomain())
+	assert.Equal(t, "Insufficient funds", err.Title())
+	assert.Equal(t, "The account savings has insufficient funds", err.Description())
+	assert.Equal(t, "SavingsHasInsufficientFunds", err.Status())
+	assert.Equal(t, http.StatusPaymentRequired, GetHttpStatusCode(err))
+	assert.True(t, IsKind(fmt.Errorf("withdraw: %w", err), "InsufficientFunds"))
+	assert.False(t, IsKind(fmt.Errorf("withdraw: %w", err), notFound))
+
+	err = err.SetProperty("checking")
+	assert.Equal(t, "The account checking has insufficient funds", err.Description())
+	assert.Equal(t, "CheckingHasInsufficientFunds", err.Status())
+
+	err = NewDomain("generic title", "generic description").SetKind("InsufficientFunds").SetProperty("checking")
+	assert.Equal(t, "The account checking has insufficient funds", err.Description())
+	assert.Equal(t, "CheckingHasInsufficientFunds", err.Status())
+
+	data, marshalErr := json.Marshal(NewHttpProblem("", "/accounts", NewKind("InsufficientFunds", "savings")))
+	assert.NoError(t, marshalErr)
+	out, parseErr := ParseHttpError(data)
+	assert.NoError(t, parseErr)
+	assert.True(t, out.IsKind("InsufficientFunds"))
+	assert.Equal(t, "SavingsHasInsufficientFunds", out.Status())
+	assert.Equal(t, "The account savings has insufficient funds", out.Description())
+
+	err = NewKind("TestUnregisteredKind", "foo")
+	assert.True(t, err.IsDomain())
+	assert.Equal(t, "TestUnregisteredKind", err.Title())
+	assert.Equal(t, "TestUnregisteredKind", err.Description())
+	assert.Equal(t, "FooTestUnregisteredKind", err.Status())
+	assert.Equal(t, http.StatusBadRequest, GetHttpStatusCode(err))
+}
+
+func TestRegisterKind_Cycle(t *testing.T) {
+	assert.NoError(t, RegisterKind(KindSpec{Name: "TestCycleKindA"}))
+	assert.NoError(t, RegisterKind(KindSpec{Name: "TestCycleKindB", Parent: "TestCycleKindA"}))
+	assert.Error(t, RegisterKind(KindSpec{Name: "TestCycleKindA", Parent: "TestCycleKindB"}))
+	assert.Error(t, RegisterKind(KindSpec{Name: "TestCycleKindA", Parent: "TestCycleKindA"}))
+	spec, _ := LookupKind("TestCycleKindA")
+	assert.Equal(t, "", spec.Parent)
+}
+
+func TestNewKind_SubKind(t *testing.T) {
+	assert.NoError(t, RegisterKind(KindSpec{Name: "SkuNotFound", Parent: notFound}))
+	err := NewKind("SkuNotFound", "sku")
+	assert.Equal(t, "SkuNotFound", err.Kind())
+	assert.True(t, err.IsKind("SkuNotFound"))
+	assert.True(t, err.IsKind(notFound))
+	assert.True(t, err.IsNotFound())
+	assert.False(t, err.IsAlreadyExists())
+	assert.True(t, err.IsDomain())
+	assert.Equal(t, "Resource not found", err.Title())
+	assert.Equal(t, "The resource sku was not found", err.Description())
+	assert.Equal(t, "SkuSkuNotFound", err.Status())
+	assert.Equal(t, http.StatusNotFound, GetHttpStatusCode(err))
+	assert.True(t, errors.Is(fmt.Errorf("get product: %w", err), ErrNotFound))
+	assert.False(t, errors.Is(err, ErrAlreadyExists))
+	assert.True(t, IsNotFound(fmt.Errorf("get product: %w", err)))
+	assert.False(t, NewNotFound("sku").IsKind("SkuNotFound"))
+
+	assert.NoError(t, RegisterKind(KindSpec{
+		Name:   "EmailAlreadyRegistered",
+		Parent: alreadyExists,
+		DescriptionFunc: func(property string) string {
+			return "The email " + property + " is already registered"
+		},
+		StatusSuffix: "EmailAlreadyRegistered",
+	}))
+	assert.NoError(t, RegisterKind(KindSpec{
+		Name:         "PrimaryEmailAlreadyRegistered",
+		Parent:       "EmailAlreadyRegistered",
+		StatusSuffix: "PrimaryEmailAlreadyRegistered",
+	}))
+	err = NewKind("PrimaryEmailAlreadyRegistered", "foo@example.com")
+	assert.True(t, err.IsKind("EmailAlreadyRegistered"))
+	assert.True(t, err.IsAlreadyExists())
+	assert.True(t, errors.Is(err, ErrAlreadyExists))
+	assert.Equal(t, "Resource already exists", err.Title())
+	assert.Equal(t, "The email foo@example.com is already registered", err.Description())
+	assert.Equal(t, "FooExampleComPrimaryEmailAlreadyRegistered", err.Status())
+	assert.Equal(t, http.StatusConflict, GetHttpStatusCode(err))
+
+	data, marshalErr := json.Marshal(NewHttpProblem("", "/users", err))
+	assert.NoError(t, marshalErr)
+	out, parseErr := ParseHttpError(data)
+	assert.NoError(t, parseErr)
+	assert.Equal(t, "PrimaryEmailAlreadyRegistered", out.Kind())
+	assert.True(t, out.IsAlreadyExists())
+	assert.Equal(t, "The email foo@example.com is already registered", out.Description())
+
+	data, marshalErr = json.Marshal(NewHttpProblem("", "/users", NewAlreadyExists("user")))
+	assert.NoError(t, marshalErr)
+	out, parseErr = ParseHttpError(data)
+	assert.NoError(t, parseErr)
+	assert.Equal(t, alreadyExists, out.Kind())
+}
@@ -0,0 +1,180 @@
+package ddderr
+
+import (
+	"strconv"
+	"time"
+)
+
+// BoundType defines how an end of a range is evaluated
+type BoundType uint8
+
+const (
+	// Unbounded the range has no limit on this end
+	Unbounded BoundType = iota
+	// Inclusive the range limit is part of the range
+	Inclusive
+	// Exclusive the range limit is not part of the range
+	Exclusive
+)
+
+// String returns the name of the bound type
+func (t BoundType) String() string {
+	switch t {
+	case Inclusive:
+		return "inclusive"
+	case Exclusive:
+		return "exclusive"
+	default:
+		return "unbounded"
+	}
+}
+
+// Bound is an end of a range.
+//
+// Value holds either a float64, a time.Duration or a time.Time and is nil if the end is unbounded
+type Bound struct {
+	Type  BoundType
+	Value interface{}
+}
+
+// FloatBound is an end of a float range, zero value is an unbounded end
+type FloatBound struct {
+	Value float64
+	Type  BoundType
+}
+
+// DurationBound is an end of a duration range, zero value is an unbounded end
+type DurationBound struct {
+	Value time.Duration
+	Type  BoundType
+}
+
+// TimeBound is an end of a time range, zero value is an unbounded end
+type TimeBound struct {
+	Value time.Time
+	Type  BoundType
+}
+
+func newBound(t BoundType, v interface{}) Bound {
+	if t == Unbounded {
+		return Bound{}
+	}
+	return Bound{Type: t, Value: v}
+}
+
+// NewOutOfRangeFloat creates an Error for Out of Range use cases of float properties
+//
+// (description e.g. The property foo is out of range [0.5,10.25), got 11)
+func NewOutOfRangeFloat(property string, value float64, lower, upper FloatBound) Error {
+	return newOutOfRangeBounds(property, value, newBound(lower.Type, lower.Value),
+		newBound(upper.Type, upper.Value))
+}
+
+// NewOutOfRangeDuration creates an Error for Out of Range use cases of duration properties
+//
+// (description e.g. The property foo must be less than or equal to 1h0m0s, got 2h0m0s)
+func NewOutOfRangeDuration(property string, value time.Duration, lower, upper DurationBound) Error {
+	return newOutOfRangeBounds(property, value, newBound(lower.Type, lower.Value),
+		newBound(upper.Type, upper.Value))
+}
+
+// NewOutOfRangeTime creates an Error for Out of Range use cases of time properties
+//
+// (description e.g. The property foo must be greater than 2021-01-01T00:00:00Z, got 2020-12-31T00:00:00Z)
+func NewOutOfRangeTime(property string, value time.Time, lower, upper TimeBound) Error {
+	return newOutOfRangeBounds(property, value, newBound(lower.Type, lower.Value),
+		newBound(upper.Type, upper.Value))
+}
+
+func newOutOfRangeBounds(property string, value interface{}, lower, upper Bound) Error {
+	return Error{
+		parent:      nil,
+		group:       domain,
+		kind:        outOfRange,
+		property:    property,
+		title:       "Property is out of the specified range",
+		description: newOutOfRangeBoundsDescription(property, value, lower, upper),
+		statusName:  getSanitizedStatusName(property, "OutOfRange"),
+		rangeValue:  value,
+		lowerBound:  lower,
+		upperBound:  upper,
+		stack:       callers(2),
+	}
+}
+
+func newOutOfRangeBoundsDescription(property string, value interface{}, lower, upper Bound) string {
+	verb, desc := "is ", "out of range"
+	switch {
+	case lower.Type != Unbounded && upper.Type != Unbounded:
+		desc = "out of range " + formatRange(lower, upper)
+	case lower.Type == Inclusive:
+		verb, desc = "", "must be greater than or equal to "+formatRangeValue(lower.Value)
+	case lower.Type == Exclusive:
+		verb, desc = "", "must be greater than "+formatRangeValue(lower.Value)
+	case upper.Type == Inclusive:
+		verb, desc = "", "must be less than or equal to "+formatRangeValue(upper.Value)
+	case upper.Type == Exclusive:
+		verb, desc = "", "must be less than "+formatRangeValue(upper.Value)
+	}
+	desc = desc + ", got " + formatRangeValue(value)
+	if property != "" {
+		desc = "The property " + property + " " + verb + desc
+	}
+	return desc
+}
+
+// formats a range using interval notation (e.g. [a,b), (-inf,b], [a,+inf))
+func formatRange(lower, upper Bound) string {
+	var start, end string
+	switch lower.Type {
+	case Inclusive:
+		start = "[" + formatRangeValue(lower.Value)
+	case Exclusive:
+		start = "(" + formatRangeValue(lower.Value)
+	default:
+		start = "(-inf"
+	}
+	switch upper.Type {
+	case Inclusive:
+		end = formatRangeValue(upper.Value) + "]"
+	case Exclusive:
+		end = formatRangeValue(upper.Value) + ")"
+	default:
+		end = "+inf)"
+	}
+	return start + "," + end
+}
+
+func formatRangeValue(v interface{}) string {
+	switch t := v.(type) {
+	case float64:
+		return strconv.FormatFloat(t, 'f', -1, 64)
+	case time.Duration:
+		return t.String()
+	case time.Time:
+		return t.Format(time.RFC3339Nano)
+	default:
+		return ""
+	}
+}
+
+// LowerBound returns the lower end of the range
+//
+// Note: Only available for Out of Range errors built with typed range constructors (e.g. NewOutOfRangeFloat)
+func (e Error) LowerBound() Bound {
+	return e.lowerBound
+}
+
+// UpperBound returns the upper end of the range
+//
+// Note: Only available for Out of Range errors built with typed range constructors (e.g. NewOutOfRangeFloat)
+func (e Error) UpperBound() Bound {
+	return e.upperBound
+}
+
+// Value returns the offending value, either a float64, a time.Duration or a time.Time
+//
+// Note: Only available for Out of Range errors built with typed range constructors (e.g. NewOutOfRangeFloat)
+func (e Error) Value() interface{} {
+	return e.rangeValue
+}
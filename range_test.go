@@ -0,0 +1,169 @@
+package ddderr
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	mockRangeStart = time.Date(2021, time.January, 1, 0, 0, 0, 0, time.UTC)
+	mockRangeEnd   = time.Date(2021, time.December, 31, 0, 0, 0, 0, time.UTC)
+)
+
+var newOutOfRangeBoundsTestSuite = []struct {
+	In        Error
+	ExpDesc   string
+	ExpValue  interface{}
+	ExpLower  Bound
+	ExpUpper  Bound
+	ExpStatus string
+}{
+	{
+		In: NewOutOfRangeFloat("price", 11, FloatBound{Value: 0.5, Type: Inclusive},
+			FloatBound{Value: 10.25, Type: Exclusive}),
+		ExpDesc:   "The property price is out of range [0.5,10.25), got 11",
+		ExpValue:  float64(11),
+		ExpLower:  Bound{Type: Inclusive, Value: 0.5},
+		ExpUpper:  Bound{Type: Exclusive, Value: 10.25},
+		ExpStatus: "PriceOutOfRange",
+	},
+	{
+		In: NewOutOfRangeFloat("", 0, FloatBound{Value: 0, Type: Exclusive},
+			FloatBound{Value: 1, Type: Inclusive}),
+		ExpDesc:   "out of range (0,1], got 0",
+		ExpValue:  float64(0),
+		ExpLower:  Bound{Type: Exclusive, Value: float64(0)},
+		ExpUpper:  Bound{Type: Inclusive, Value: float64(1)},
+		ExpStatus: "OutOfRange",
+	},
+	{
+		In:        NewOutOfRangeFloat("quantity", 0, FloatBound{Value: 1, Type: Inclusive}, FloatBound{}),
+		ExpDesc:   "The property quantity must be greater than or equal to 1, got 0",
+		ExpValue:  float64(0),
+		ExpLower:  Bound{Type: Inclusive, Value: float64(1)},
+		ExpUpper:  Bound{},
+		ExpStatus: "QuantityOutOfRange",
+	},
+	{
+		In:        NewOutOfRangeFloat("quantity", 0, FloatBound{Value: 0, Type: Exclusive}, FloatBound{}),
+		ExpDesc:   "The property quantity must be greater than 0, got 0",
+		ExpValue:  float64(0),
+		ExpLower:  Bound{Type: Exclusive, Value: float64(0)},
+		ExpUpper:  Bound{},
+		ExpStatus: "QuantityOutOfRange",
+	},
+	{
+		In:        NewOutOfRangeFloat("discount", 1.5, FloatBound{}, FloatBound{Value: 1, Type: Inclusive}),
+		ExpDesc:   "The property discount must be less than or equal to 1, got 1.5",
+		ExpValue:  1.5,
+		ExpLower:  Bound{},
+		ExpUpper:  Bound{Type: Inclusive, Value: float64(1)},
+		ExpStatus: "DiscountOutOfRange",
+	},
+	{
+		In:        NewOutOfRangeFloat("", 1.5, FloatBound{}, FloatBound{}),
+		ExpDesc:   "out of range, got 1.5",
+		ExpValue:  1.5,
+		ExpStatus: "OutOfRange",
+	},
+	{
+		In:        NewOutOfRangeFloat("price", 5, FloatBound{}, FloatBound{}),
+		ExpDesc:   "The property price is out of range, got 5",
+		ExpValue:  float64(5),
+		ExpStatus: "PriceOutOfRange",
+	},
+	{
+		In: NewOutOfRangeDuration("ttl", time.Hour*2, DurationBound{},
+			DurationBound{Value: time.Hour, Type: Exclusive}),
+		ExpDesc:   "The property ttl must be less than 1h0m0s, got 2h0m0s",
+		ExpValue:  time.Hour * 2,
+		ExpLower:  Bound{},
+		ExpUpper:  Bound{Type: Exclusive, Value: time.Hour},
+		ExpStatus: "TtlOutOfRange",
+	},
+	{
+		In: NewOutOfRangeDuration("ttl", time.Millisecond, DurationBound{Value: time.Second, Type: Inclusive},
+			DurationBound{Value: time.Hour, Type: Inclusive}),
+		ExpDesc:   "The property ttl is out of range [1s,1h0m0s], got 1ms",
+		ExpValue:  time.Millisecond,
+		ExpLower:  Bound{Type: Inclusive, Value: time.Second},
+		ExpUpper:  Bound{Type: Inclusive, Value: time.Hour},
+		ExpStatus: "TtlOutOfRange",
+	},
+	{
+		In: NewOutOfRangeTime("scheduled_at", mockRangeStart.Add(-time.Hour),
+			TimeBound{Value: mockRangeStart, Type: Inclusive}, TimeBound{Value: mockRangeEnd, Type: Exclusive}),
+		ExpDesc:   "The property scheduled_at is out of range [2021-01-01T00:00:00Z,2021-12-31T00:00:00Z), got 2020-12-31T23:00:00Z",
+		ExpValue:  mockRangeStart.Add(-time.Hour),
+		ExpLower:  Bound{Type: Inclusive, Value: mockRangeStart},
+		ExpUpper:  Bound{Type: Exclusive, Value: mockRangeEnd},
+		ExpStatus: "ScheduledAtOutOfRange",
+	},
+	{
+		In: NewOutOfRangeTime("scheduled_at", mockRangeStart, TimeBound{Value: mockRangeStart, Type: Exclusive},
+			TimeBound{}),
+		ExpDesc:   "The property scheduled_at must be greater than 2021-01-01T00:00:00Z, got 2021-01-01T00:00:00Z",
+		ExpValue:  mockRangeStart,
+		ExpLower:  Bound{Type: Exclusive, Value: mockRangeStart},
+		ExpUpper:  Bound{},
+		ExpStatus: "ScheduledAtOutOfRange",
+	},
+}
+
+func TestNewOutOfRangeBounds(t *testing.T) {
+	for _, tt := range newOutOfRangeBoundsTestSuite {
+		t.Run("", func(t *testing.T) {
+			assert.Equal(t, tt.ExpDesc, tt.In.Description())
+			assert.Equal(t, tt.ExpStatus, tt.In.Status())
+			assert.Equal(t, tt.ExpValue, tt.In.Value())
+			assert.Equal(t, tt.ExpLower, tt.In.LowerBound())
+			assert.Equal(t, tt.ExpUpper, tt.In.UpperBound())
+			assert.Equal(t, "Property is out of the specified range", tt.In.Title())
+			assert.True(t, tt.In.IsOutOfRange())
+			assert.True(t, tt.In.IsDomain())
+
+			data, err := json.Marshal(tt.In)
+			assert.NoError(t, err)
+			var out Error
+			assert.NoError(t, json.Unmarshal(data, &out))
+			assert.EqualValues(t, tt.In, out)
+			assert.Equal(t, tt.ExpDesc, out.Description())
+		})
+	}
+}
+
+func TestNewOutOfRangeFloat_NonFiniteJSON(t *testing.T) {
+	in := NewOutOfRangeFloat("price", math.Inf(1), FloatBound{Value: math.Inf(-1), Type: Exclusive},
+		FloatBound{Value: 10.25, Type: Inclusive})
+	data, err := json.Marshal(in)
+	assert.NoError(t, err)
+	var out Error
+	assert.NoError(t, json.Unmarshal(data, &out))
+	assert.EqualValues(t, in, out)
+	assert.Equal(t, in.Description(), out.Description())
+
+	data, err = json.Marshal(Errors{NewOutOfRangeFloat("price", math.NaN(), FloatBound{Value: 0, Type: Inclusive},
+		FloatBound{})})
+	assert.NoError(t, err)
+	var outErrs Errors
+	assert.NoError(t, json.Unmarshal(data, &outErrs))
+	assert.Len(t, outErrs, 1)
+	assert.True(t, math.IsNaN(outErrs[0].Value().(float64)))
+}
+
+func TestNewOutOfRangeBounds_SetProperty(t *testing.T) {
+	err := NewOutOfRangeFloat("price", 11, FloatBound{Value: 0.5, Type: Inclusive},
+		FloatBound{Value: 10.25, Type: Exclusive}).SetProperty("amount")
+	assert.Equal(t, "The property amount is out of range [0.5,10.25), got 11", err.Description())
+	assert.Equal(t, "AmountOutOfRange", err.Status())
+}
+
+func TestBoundType_String(t *testing.T) {
+	assert.Equal(t, "unbounded", Unbounded.String())
+	assert.Equal(t, "inclusive", Inclusive.String())
+	assert.Equal(t, "exclusive", Exclusive.String())
+}
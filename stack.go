@@ -0,0 +1,77 @@
+package ddderr
+
+import (
+	"runtime"
+	"sync/atomic"
+)
+
+// maximum number of frames recorded by a stack trace
+const maxStackDepth = 32
+
+// stackTraceEnabled is accessed atomically (0 = disabled, 1 = enabled)
+var stackTraceEnabled int32
+
+// SetStackTraceEnabled enables or disables the call stack capture on every Error constructor.
+//
+// Stack trace capture is disabled by default, use Error.WithStackTrace to capture the call stack of a
+// specific Error instead
+func SetStackTraceEnabled(enabled bool) {
+	var v int32
+	if enabled {
+		v = 1
+	}
+	atomic.StoreInt32(&stackTraceEnabled, v)
+}
+
+// StackTraceEnabled checks if the call stack capture is enabled for every Error constructor
+func StackTraceEnabled() bool {
+	return atomic.LoadInt32(&stackTraceEnabled) == 1
+}
+
+// records the call stack only if the capture was enabled globally.
+//
+// skip is the number of stack frames to skip, 0 identifies the caller of callers
+func callers(skip int) []uintptr {
+	if atomic.LoadInt32(&stackTraceEnabled) == 0 {
+		return nil
+	}
+	return captureStack(skip + 1)
+}
+
+// records the call stack.
+//
+// skip is the number of stack frames to skip, 0 identifies the caller of captureStack
+func captureStack(skip int) []uintptr {
+	var pcs [maxStackDepth]uintptr
+	n := runtime.Callers(skip+2, pcs[:])
+	stack := make([]uintptr, n)
+	copy(stack, pcs[:n])
+	return stack
+}
+
+// StackTrace retrieves the call stack recorded when the Error was built
+//
+// Note: Might return nil if stack trace capture was not enabled
+func (e Error) StackTrace() []runtime.Frame {
+	if len(e.stack) == 0 {
+		return nil
+	}
+
+	frames := runtime.CallersFrames(e.stack)
+	trace := make([]runtime.Frame, 0, len(e.stack))
+	for {
+		frame, more := frames.Next()
+		trace = append(trace, frame)
+		if !more {
+			break
+		}
+	}
+	return trace
+}
+
+// WithStackTrace records the call stack of the caller into the Error, regardless of the global stack trace
+// capture setting
+func (e Error) WithStackTrace() Error {
+	e.stack = captureStack(1)
+	return e
+}
@@ -0,0 +1,60 @@
+package ddderr
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newMockedRepositoryError() Error {
+	return NewRemoteCall("localhost:5432")
+}
+
+func TestError_StackTrace(t *testing.T) {
+	assert.False(t, StackTraceEnabled())
+	assert.Nil(t, newMockedRepositoryError().StackTrace())
+
+	SetStackTraceEnabled(true)
+	defer SetStackTraceEnabled(false)
+	assert.True(t, StackTraceEnabled())
+
+	trace := newMockedRepositoryError().StackTrace()
+	if assert.NotEmpty(t, trace) {
+		assert.True(t, strings.HasSuffix(trace[0].Function, ".newMockedRepositoryError"))
+		assert.True(t, strings.HasSuffix(trace[1].Function, ".TestError_StackTrace"))
+		assert.True(t, strings.HasSuffix(trace[0].File, "stack_test.go"))
+	}
+
+	trace = NewOutOfRangeFloat("price", 11, FloatBound{}, FloatBound{}).StackTrace()
+	if assert.NotEmpty(t, trace) {
+		assert.True(t, strings.HasSuffix(trace[0].Function, ".TestError_StackTrace"))
+	}
+}
+
+func TestError_WithStackTrace(t *testing.T) {
+	err := NewNotFound("foo")
+	assert.Nil(t, err.StackTrace())
+
+	trace := err.WithStackTrace().StackTrace()
+	if assert.NotEmpty(t, trace) {
+		assert.True(t, strings.HasSuffix(trace[0].Function, ".TestError_WithStackTrace"))
+	}
+	assert.True(t, len(trace) <= maxStackDepth)
+}
+
+func TestCallers_Disabled(t *testing.T) {
+	allocs := testing.AllocsPerRun(100, func() {
+		_ = callers(0)
+	})
+	assert.Zero(t, allocs)
+}
+
+func BenchmarkNewNotFound_StackTrace(b *testing.B) {
+	SetStackTraceEnabled(true)
+	defer SetStackTraceEnabled(false)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = NewNotFound("foo")
+	}
+}
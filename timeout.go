@@ -0,0 +1,31 @@
+package ddderr
+
+import (
+	"context"
+	"errors"
+	"net"
+)
+
+// FromTimeoutError builds a Timeout or Canceled Error from the given error, the given error is attached as
+// parent.
+//
+// context.DeadlineExceeded and any net.Error reporting a timeout are built as Timeout errors while
+// context.Canceled is built as a Canceled error. Returns false if the given error is none of them
+func FromTimeoutError(externalResource string, err error) (Error, bool) {
+	if err == nil {
+		return Error{}, false
+	}
+
+	switch {
+	case errors.Is(err, context.DeadlineExceeded):
+		return NewTimeout(externalResource, 0).SetParent(err), true
+	case errors.Is(err, context.Canceled):
+		return NewCanceled(externalResource).SetParent(err), true
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return NewTimeout(externalResource, 0).SetParent(err), true
+	}
+	return Error{}, false
+}
@@ -0,0 +1,100 @@
+package ddderr
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type mockNetError struct {
+	timeout bool
+}
+
+var _ net.Error = mockNetError{}
+
+func (e mockNetError) Error() string {
+	return "i/o error"
+}
+
+func (e mockNetError) Timeout() bool {
+	return e.timeout
+}
+
+func (e mockNetError) Temporary() bool {
+	return false
+}
+
+var fromTimeoutErrorTestSuite = []struct {
+	InErr      error
+	ExpOk      bool
+	ExpTimeout bool
+}{
+	{
+		InErr: nil,
+		ExpOk: false,
+	},
+	{
+		InErr: errors.New("generic error"),
+		ExpOk: false,
+	},
+	{
+		InErr:      context.DeadlineExceeded,
+		ExpOk:      true,
+		ExpTimeout: true,
+	},
+	{
+		InErr:      fmt.Errorf("query users: %w", context.DeadlineExceeded),
+		ExpOk:      true,
+		ExpTimeout: true,
+	},
+	{
+		InErr:      fmt.Errorf("query users: %w", context.Canceled),
+		ExpOk:      true,
+		ExpTimeout: false,
+	},
+	{
+		InErr:      &net.OpError{Op: "dial", Net: "tcp", Err: mockNetError{timeout: true}},
+		ExpOk:      true,
+		ExpTimeout: true,
+	},
+	{
+		InErr: &net.OpError{Op: "dial", Net: "tcp", Err: mockNetError{timeout: false}},
+		ExpOk: false,
+	},
+}
+
+func TestFromTimeoutError(t *testing.T) {
+	for _, tt := range fromTimeoutErrorTestSuite {
+		t.Run("", func(t *testing.T) {
+			err, ok := FromTimeoutError("localhost:5432", tt.InErr)
+			assert.Equal(t, tt.ExpOk, ok)
+			if !tt.ExpOk {
+				assert.Empty(t, err.Kind())
+				return
+			}
+			assert.Equal(t, tt.ExpTimeout, err.IsTimeout())
+			assert.Equal(t, !tt.ExpTimeout, err.IsCanceled())
+			assert.True(t, err.IsInfrastructure())
+			assert.Equal(t, "localhost:5432", err.Property())
+			assert.Equal(t, tt.InErr, err.Parent())
+			assert.True(t, errors.Is(err, tt.InErr))
+		})
+	}
+}
+
+func TestContextTimeout(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Nanosecond)
+	defer cancel()
+	<-ctx.Done()
+
+	err, ok := FromTimeoutError("localhost:5432", ctx.Err())
+	assert.True(t, ok)
+	assert.True(t, IsTimeout(fmt.Errorf("query users: %w", err)))
+	assert.True(t, errors.Is(err, ErrTimeout))
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+}